@@ -42,9 +42,20 @@ func main() {
 	// Initialize Repositories
 	boardRepo := postgres.NewBoardRepository(pool)
 	taskRepo := postgres.NewTaskRepository(pool)
+	checklistRepo := postgres.NewChecklistRepository(pool)
+	dependencyRepo := postgres.NewDependencyRepository(pool)
+	commentRepo := postgres.NewCommentRepository(pool)
+	activityRepo := postgres.NewActivityRepository(pool)
+	idempotencyRepo := postgres.NewIdempotencyRepository(pool)
 
 	// Initialize API Router
-	router := api.NewRouter(boardRepo, taskRepo, cfg)
+	router := api.NewRouter(boardRepo, taskRepo, checklistRepo, dependencyRepo, commentRepo, activityRepo, idempotencyRepo, cfg)
+
+	// Keep task ranks short, expire stored idempotent responses and delete
+	// expired boards in the background
+	go router.RunRankRebalancer(context.Background())
+	go router.RunIdempotencySweeper(context.Background())
+	go router.RunBoardReaper(context.Background())
 
 	// Start server
 	addr := fmt.Sprintf(":%s", cfg.Port)
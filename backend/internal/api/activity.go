@@ -0,0 +1,139 @@
+package api
+
+import (
+	"log"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	// actorHeader optionally names who is making a change, for the activity log.
+	actorHeader = "X-Kanbin-Actor"
+
+	defaultActivityPageSize = 50
+	maxActivityPageSize     = 200
+)
+
+type ActivityPage struct {
+	Entries    []*domain.Activity `json:"entries"`
+	NextCursor string             `json:"next_cursor,omitempty"`
+}
+
+// actorFromRequest returns the trimmed actor header, truncated to fit the
+// activity table.
+func actorFromRequest(req *http.Request) string {
+	actor := strings.TrimSpace(req.Header.Get(actorHeader))
+	if len(actor) > maxAssigneeLength {
+		actor = actor[:maxAssigneeLength]
+	}
+	return actor
+}
+
+// taskFields returns the user-editable fields of a task keyed by their JSON name.
+func taskFields(t *domain.Task) map[string]interface{} {
+	labels := t.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	var dueAt interface{}
+	if t.DueAt != nil {
+		dueAt = t.DueAt.UTC().Format(time.RFC3339)
+	}
+	return map[string]interface{}{
+		"title":       t.Title,
+		"description": t.Description,
+		"status":      string(t.Status),
+		"position":    t.Position,
+		"labels":      labels,
+		"assignee":    t.Assignee,
+		"due_at":      dueAt,
+		"priority":    string(t.Priority),
+	}
+}
+
+// diffTask returns the fields that differ between before and after. Either
+// side may be nil to describe a creation or deletion.
+func diffTask(before, after *domain.Task) map[string]domain.FieldChange {
+	var beforeFields, afterFields map[string]interface{}
+	if before != nil {
+		beforeFields = taskFields(before)
+	}
+	if after != nil {
+		afterFields = taskFields(after)
+	}
+
+	changes := make(map[string]domain.FieldChange)
+	for _, field := range []string{"title", "description", "status", "position", "labels", "assignee", "due_at", "priority"} {
+		b, a := beforeFields[field], afterFields[field]
+		if before != nil && after != nil && reflect.DeepEqual(b, a) {
+			continue
+		}
+		changes[field] = domain.FieldChange{Before: b, After: a}
+	}
+	return changes
+}
+
+// recordActivity appends an entry to the board's activity log. Failures are
+// logged rather than returned so auditing never blocks the change itself.
+func (r *Router) recordActivity(req *http.Request, boardID, taskID uuid.UUID, action string, changes map[string]domain.FieldChange) {
+	activity := &domain.Activity{
+		BoardID:   boardID,
+		TaskID:    taskID,
+		Action:    action,
+		Actor:     actorFromRequest(req),
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+	if err := r.activityRepo.Create(req.Context(), activity); err != nil {
+		log.Printf("Failed to record %s activity for task %s: %v", action, taskID, err)
+	}
+}
+
+func (r *Router) handleListActivity(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	limit := defaultActivityPageSize
+	if v := req.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxActivityPageSize {
+			respondError(w, http.StatusBadRequest, "limit must be between 1 and 200")
+			return
+		}
+		limit = n
+	}
+
+	var before int64
+	if v := req.URL.Query().Get("cursor"); v != "" {
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil || n < 1 {
+			respondError(w, http.StatusBadRequest, "Invalid cursor")
+			return
+		}
+		before = n
+	}
+
+	entries, err := r.activityRepo.ListByBoardID(req.Context(), board.ID, before, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch activity")
+		return
+	}
+
+	page := ActivityPage{Entries: entries}
+	if page.Entries == nil {
+		page.Entries = []*domain.Activity{}
+	}
+	if len(entries) == limit {
+		page.NextCursor = strconv.FormatInt(entries[len(entries)-1].ID, 10)
+	}
+	respondJSON(w, http.StatusOK, page)
+}
@@ -0,0 +1,118 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func getActivity(t *testing.T, r *Router, query string) ActivityPage {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"/activity"+query, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var page ActivityPage
+	if err := json.NewDecoder(rr.Body).Decode(&page); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return page
+}
+
+func TestActivity_RecordsTaskLifecycle(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ship it"}`))
+	req.Header.Set(actorHeader, "agent-7")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var task domain.Task
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	taskPath := "/api/boards/" + testKey + "/tasks/" + task.ID.String()
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, taskPath, strings.NewReader(`{"title":"ship it now"}`)))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, taskPath, strings.NewReader(`{"status":"DONE"}`)))
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, taskPath, nil))
+
+	page := getActivity(t, r, "")
+	var actions []string
+	for _, e := range page.Entries {
+		actions = append(actions, e.Action)
+	}
+	want := "task.archived,task.moved,task.updated,task.created"
+	if strings.Join(actions, ",") != want {
+		t.Fatalf("expected %s, got %v", want, actions)
+	}
+
+	created := page.Entries[3]
+	if created.Actor != "agent-7" {
+		t.Errorf("expected actor agent-7, got %q", created.Actor)
+	}
+	moved := page.Entries[1].Changes["status"]
+	if moved.Before != "TODO" || moved.After != "DONE" {
+		t.Errorf("unexpected status change: %+v", moved)
+	}
+	if _, ok := page.Entries[2].Changes["status"]; ok {
+		t.Error("title-only update must not record a status change")
+	}
+	if archived := page.Entries[0].Changes["title"]; archived.Before != "ship it now" {
+		t.Errorf("expected archive entry to record the task, got %+v", page.Entries[0].Changes)
+	}
+}
+
+func TestActivity_RecordsBatchArchive(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	rr := postBatch(t, r, `{"operations":[{"op":"delete","id":"`+task.ID.String()+`"}]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	page := getActivity(t, r, "")
+	if len(page.Entries) != 1 || page.Entries[0].Action != domain.ActivityTaskArchived {
+		t.Fatalf("expected one archive entry, got %+v", page.Entries)
+	}
+	if page.Entries[0].Changes == nil {
+		t.Error("expected archive entry to carry changes")
+	}
+}
+
+func TestActivity_Pagination(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	for i := 0; i < 3; i++ {
+		req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"t"}`))
+		r.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	first := getActivity(t, r, "?limit=2")
+	if len(first.Entries) != 2 || first.NextCursor == "" {
+		t.Fatalf("expected a full first page with a cursor, got %d entries, cursor %q", len(first.Entries), first.NextCursor)
+	}
+	second := getActivity(t, r, "?limit=2&cursor="+first.NextCursor)
+	if len(second.Entries) != 1 || second.NextCursor != "" {
+		t.Errorf("expected a final page of 1 entry, got %d entries, cursor %q", len(second.Entries), second.NextCursor)
+	}
+}
+
+func TestActivity_InvalidLimit_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"/activity?limit=0", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,90 @@
+package api
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+// handleRestoreTask brings an archived task back onto the board. The task
+// returns to its old lane, or to the first lane if that lane has since been
+// removed, and is subject to the same task and WIP limits as a new task.
+func (r *Router) handleRestoreTask(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+	if task.ArchivedAt == nil {
+		respondError(w, http.StatusConflict, "Task is not archived")
+		return
+	}
+
+	count, err := r.taskRepo.CountByBoardID(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check task limit")
+		return
+	}
+	if count >= maxTasksPerBoard {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Task limit reached (%d)", maxTasksPerBoard))
+		return
+	}
+
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
+		return
+	}
+	before := *task
+	if findLaneByName(lanes, task.Status) == nil && len(lanes) > 0 {
+		task.Status = lanes[0].Name
+	}
+
+	task.ArchivedAt = nil
+	task.Position = endOfLane
+	task.UpdatedAt = time.Now()
+	if err := r.taskRepo.Update(req.Context(), task); err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			respondError(w, http.StatusConflict, wipLimitMessage(task.Status))
+			return
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			status, msg := versionConflict(req)
+			respondError(w, status, msg)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to restore task")
+		return
+	}
+	r.recordActivity(req, board.ID, task.ID, domain.ActivityTaskRestored, diffTask(&before, task))
+	r.publish(board.ID, eventTaskCreated, task)
+
+	respondTask(w, http.StatusOK, task)
+}
+
+// handlePurgeTask permanently deletes an archived task. Only archived tasks
+// can be purged so that a single request can never lose data.
+func (r *Router) handlePurgeTask(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+	if task.ArchivedAt == nil {
+		respondError(w, http.StatusConflict, "Archive the task before purging it")
+		return
+	}
+	if !checkIfMatch(w, req, task) {
+		return
+	}
+
+	if err := r.taskRepo.Delete(req.Context(), task.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete task")
+		return
+	}
+	r.recordActivity(req, board.ID, task.ID, domain.ActivityTaskDeleted, diffTask(task, nil))
+	r.publish(board.ID, eventTaskDeleted, TaskDeletedEvent{ID: task.ID})
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
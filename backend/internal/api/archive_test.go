@@ -0,0 +1,148 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func taskPath(task *domain.Task) string {
+	return "/api/boards/" + testKey + "/tasks/" + task.ID.String()
+}
+
+func boardTaskCount(t *testing.T, r *Router, query string) int {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+query, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var resp BoardResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return len(resp.Tasks)
+}
+
+func TestDeleteTask_ArchivesAndHidesFromBoard(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	seedTask(tr, board.ID)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, taskPath(task), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if stored, ok := tr.tasks[task.ID]; !ok || stored.ArchivedAt == nil {
+		t.Fatal("expected the task to be archived, not deleted")
+	}
+
+	if n := boardTaskCount(t, r, ""); n != 1 {
+		t.Errorf("expected 1 task in the default view, got %d", n)
+	}
+	if n := boardTaskCount(t, r, "?include=archived"); n != 2 {
+		t.Errorf("expected 2 tasks with include=archived, got %d", n)
+	}
+	if count, _ := tr.CountByBoardID(context.Background(), board.ID); count != 1 {
+		t.Errorf("archived tasks must not count toward the task limit, got %d", count)
+	}
+}
+
+func TestUpdateTask_Archived_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, taskPath(task), nil))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, taskPath(task), nil))
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+}
+
+func TestRestoreTask(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, taskPath(task)+"/restore", nil))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("restoring an active task: expected 409, got %d", rr.Code)
+	}
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, taskPath(task), nil))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, taskPath(task)+"/restore", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if task.ArchivedAt != nil {
+		t.Error("expected archived_at to be cleared")
+	}
+	if n := boardTaskCount(t, r, ""); n != 1 {
+		t.Errorf("expected the restored task on the board, got %d tasks", n)
+	}
+}
+
+func TestRestoreTask_RemovedLane_MovesToFirstLane(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	task.Status = "Gone"
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, taskPath(task), nil))
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, taskPath(task)+"/restore", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if status := tr.tasks[task.ID].Status; status != domain.StatusTodo {
+		t.Errorf("expected status TODO, got %s", status)
+	}
+}
+
+func TestRestoreTask_FullLane_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, taskPath(task), nil))
+	laneByName(t, br, board.ID, domain.StatusTodo).WIPLimit = 1
+	seedTask(tr, board.ID)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, taskPath(task)+"/restore", nil))
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+}
+
+func TestPurgeTask(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, taskPath(task)+"/purge", nil))
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("purging an active task: expected 409, got %d", rr.Code)
+	}
+
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, taskPath(task), nil))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, taskPath(task)+"/purge", nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if _, ok := tr.tasks[task.ID]; ok {
+		t.Error("expected the task to be deleted permanently")
+	}
+}
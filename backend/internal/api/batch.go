@@ -0,0 +1,255 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const maxBatchOperations = 100
+
+// Batch operation names accepted in BatchOp.Op.
+const (
+	batchCreate = "create"
+	batchUpdate = "update"
+	batchMove   = "move"
+	batchDelete = "delete"
+)
+
+// BatchReq is the body of POST /boards/{key}/batch.
+type BatchReq struct {
+	Operations []BatchOp `json:"operations"`
+}
+
+// BatchOp is a single operation in a batch. Task holds a CreateTaskReq for
+// create, an UpdateTaskReq for update and a MoveTaskReq for move, which
+// behaves like POST /tasks/{id}/move; delete takes only ID and archives the
+// task like DELETE /tasks/{id}.
+type BatchOp struct {
+	Op   string          `json:"op"`
+	ID   uuid.UUID       `json:"id,omitempty"`
+	Task json.RawMessage `json:"task,omitempty"`
+}
+
+type BatchResult struct {
+	Op   string       `json:"op"`
+	Task *domain.Task `json:"task"`
+}
+
+type BatchResponse struct {
+	Results []BatchResult `json:"results"`
+}
+
+// BatchFailure is returned when any operation is rejected. Index is the
+// zero-based position of the failing operation; nothing was applied.
+type BatchFailure struct {
+	Error string `json:"error"`
+	Index int    `json:"index"`
+}
+
+// batchState replays a batch against an in-memory copy of the board so that
+// every operation is validated against the effects of the ones before it.
+type batchState struct {
+	board  *domain.Board
+	lanes  []*domain.Lane
+	deps   []*domain.Dependency
+	tasks  map[uuid.UUID]*domain.Task
+	active int // unarchived tasks, for the board's task limit
+}
+
+// apply validates op and returns the write it implies, or a status and
+// message when the operation is rejected.
+func (s *batchState) apply(op BatchOp) (domain.TaskWrite, int, string) {
+	if op.Op == batchCreate {
+		if s.active >= maxTasksPerBoard {
+			return domain.TaskWrite{}, http.StatusUnprocessableEntity, fmt.Sprintf("Task limit reached (%d)", maxTasksPerBoard)
+		}
+		var reqBody CreateTaskReq
+		if err := json.Unmarshal(op.Task, &reqBody); err != nil {
+			return domain.TaskWrite{}, http.StatusBadRequest, "Invalid task payload"
+		}
+		task, msg := newTask(s.board.ID, reqBody, s.lanes)
+		if msg != "" {
+			return domain.TaskWrite{}, http.StatusBadRequest, msg
+		}
+		s.tasks[task.ID] = task
+		s.active++
+		return domain.TaskWrite{Create: true, Task: task}, 0, ""
+	}
+
+	current, ok := s.tasks[op.ID]
+	if op.ID == uuid.Nil || !ok {
+		return domain.TaskWrite{}, http.StatusNotFound, "Task not found on this board"
+	}
+	if current.ArchivedAt != nil {
+		return domain.TaskWrite{}, http.StatusConflict, archivedTaskMessage
+	}
+	// Work on a copy so earlier results keep the state they were written with.
+	task := *current
+	var neighbour *domain.Neighbour
+
+	switch op.Op {
+	case batchUpdate, batchMove:
+		var reqBody UpdateTaskReq
+		if op.Op == batchMove {
+			var move MoveTaskReq
+			if err := json.Unmarshal(op.Task, &move); err != nil {
+				return domain.TaskWrite{}, http.StatusBadRequest, "Invalid task payload"
+			}
+			var msg string
+			if reqBody, neighbour, msg = moveUpdate(task.ID, move); msg != "" {
+				return domain.TaskWrite{}, http.StatusBadRequest, msg
+			}
+			if neighbour != nil && !validNeighbour(&task, s.tasks[neighbour.ID], move.Status) {
+				return domain.TaskWrite{}, http.StatusBadRequest, neighbourMessage(move.Status)
+			}
+		} else if err := json.Unmarshal(op.Task, &reqBody); err != nil {
+			return domain.TaskWrite{}, http.StatusBadRequest, "Invalid task payload"
+		}
+		if msg := applyTaskUpdate(&task, reqBody, s.lanes); msg != "" {
+			return domain.TaskWrite{}, http.StatusBadRequest, msg
+		}
+		if s.board.EnforceDependencies && task.Status != current.Status && task.Status == finalLane(s.lanes) {
+			if open := s.openBlockers(task.ID); open > 0 {
+				return domain.TaskWrite{}, http.StatusConflict, blockedMessage(open)
+			}
+		}
+	case batchDelete:
+		now := time.Now()
+		task.ArchivedAt = &now
+		task.UpdatedAt = now
+		s.active--
+	default:
+		return domain.TaskWrite{}, http.StatusBadRequest, "op must be one of create, update, move, delete"
+	}
+
+	s.tasks[task.ID] = &task
+	return domain.TaskWrite{Task: &task, Neighbour: neighbour}, 0, ""
+}
+
+// openBlockers is the in-batch counterpart of Router.openBlockers.
+func (s *batchState) openBlockers(taskID uuid.UUID) int {
+	done := finalLane(s.lanes)
+	open := 0
+	for _, d := range s.deps {
+		if d.TaskID != taskID {
+			continue
+		}
+		if blocker, ok := s.tasks[d.BlockedByID]; ok && blocker.Status != done && blocker.ArchivedAt == nil {
+			open++
+		}
+	}
+	return open
+}
+
+// handleBatch validates every operation against the board as it will look
+// after the preceding ones, then applies them all in one transaction. Either
+// every operation takes effect or none does.
+func (r *Router) handleBatch(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	var reqBody BatchReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if len(reqBody.Operations) == 0 {
+		respondError(w, http.StatusBadRequest, "operations must not be empty")
+		return
+	}
+	if len(reqBody.Operations) > maxBatchOperations {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("A batch can have at most %d operations", maxBatchOperations))
+		return
+	}
+
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
+		return
+	}
+	existing, err := r.taskRepo.GetByBoardID(req.Context(), board.ID, domain.TaskFilter{IncludeArchived: true})
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch tasks")
+		return
+	}
+	deps, err := r.dependencyRepo.ListByBoardID(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch dependencies")
+		return
+	}
+
+	state := &batchState{board: board, lanes: lanes, deps: deps, tasks: make(map[uuid.UUID]*domain.Task, len(existing))}
+	for _, t := range existing {
+		state.tasks[t.ID] = t
+		if t.ArchivedAt == nil {
+			state.active++
+		}
+	}
+
+	writes := make([]domain.TaskWrite, len(reqBody.Operations))
+	befores := make([]*domain.Task, len(reqBody.Operations))
+	for i, op := range reqBody.Operations {
+		befores[i] = state.tasks[op.ID]
+		write, status, msg := state.apply(op)
+		if msg != "" {
+			respondJSON(w, status, BatchFailure{Error: fmt.Sprintf("operation %d: %s", i, msg), Index: i})
+			return
+		}
+		writes[i] = write
+	}
+
+	if err := r.taskRepo.ApplyBatch(req.Context(), writes); err != nil {
+		var batchErr *domain.BatchError
+		if errors.As(err, &batchErr) {
+			var msg string
+			switch {
+			case errors.Is(err, domain.ErrWIPLimitReached):
+				msg = wipLimitMessage(writes[batchErr.Index].Task.Status)
+			case errors.Is(err, domain.ErrVersionConflict):
+				msg = taskChangedMessage
+			case errors.Is(err, domain.ErrNeighbourNotInLane):
+				msg = neighbourMessage(writes[batchErr.Index].Task.Status)
+			}
+			if msg != "" {
+				respondJSON(w, http.StatusConflict, BatchFailure{Error: fmt.Sprintf("operation %d: %s", batchErr.Index, msg), Index: batchErr.Index})
+				return
+			}
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to apply batch")
+		return
+	}
+
+	results := make([]BatchResult, len(writes))
+	for i, op := range reqBody.Operations {
+		task := writes[i].Task
+		results[i] = BatchResult{Op: op.Op, Task: task}
+		switch {
+		case op.Op == batchCreate:
+			r.recordActivity(req, board.ID, task.ID, domain.ActivityTaskCreated, diffTask(nil, task))
+			r.publish(board.ID, eventTaskCreated, task)
+		case op.Op == batchDelete:
+			r.recordActivity(req, board.ID, task.ID, domain.ActivityTaskArchived, diffTask(task, nil))
+			r.publish(board.ID, eventTaskDeleted, TaskDeletedEvent{ID: task.ID, Archived: true})
+		default:
+			r.publish(board.ID, eventTaskUpdated, task)
+			if changes := diffTask(befores[i], task); len(changes) > 0 {
+				action := domain.ActivityTaskUpdated
+				if befores[i].Status != task.Status {
+					action = domain.ActivityTaskMoved
+				}
+				r.recordActivity(req, board.ID, task.ID, action, changes)
+			}
+		}
+	}
+
+	respondJSON(w, http.StatusOK, BatchResponse{Results: results})
+}
@@ -0,0 +1,132 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func postBatch(t *testing.T, r *Router, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/batch", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestBatch_AppliesAllOperations(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	edit := seedTask(tr, board.ID)
+	move := seedTask(tr, board.ID)
+	drop := seedTask(tr, board.ID)
+
+	body := `{"operations":[
+		{"op":"create","task":{"title":"plan A","labels":["plan"]}},
+		{"op":"create","task":{"title":"plan B","status":"IN_PROGRESS"}},
+		{"op":"update","id":"` + edit.ID.String() + `","task":{"title":"edited"}},
+		{"op":"move","id":"` + move.ID.String() + `","task":{"status":"DONE"}},
+		{"op":"delete","id":"` + drop.ID.String() + `"}
+	]}`
+	rr := postBatch(t, r, body)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp BatchResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Results) != 5 {
+		t.Fatalf("expected 5 results, got %d", len(resp.Results))
+	}
+	if resp.Results[1].Task.Status != domain.StatusInProgress {
+		t.Errorf("expected created task in IN_PROGRESS, got %s", resp.Results[1].Task.Status)
+	}
+	if len(tr.tasks) != 5 {
+		t.Errorf("expected 5 stored tasks, got %d", len(tr.tasks))
+	}
+	if tr.tasks[edit.ID].Title != "edited" || tr.tasks[move.ID].Status != domain.StatusDone {
+		t.Error("expected update and move to be applied")
+	}
+	if tr.tasks[drop.ID].ArchivedAt == nil {
+		t.Error("expected delete to archive the task")
+	}
+}
+
+func TestBatch_InvalidOperation_AppliesNothing(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	body := `{"operations":[
+		{"op":"create","task":{"title":"fine"}},
+		{"op":"move","id":"` + task.ID.String() + `","task":{"status":"NOWHERE"}}
+	]}`
+	rr := postBatch(t, r, body)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+	var failure BatchFailure
+	if err := json.NewDecoder(rr.Body).Decode(&failure); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if failure.Index != 1 {
+		t.Errorf("expected failing index 1, got %d", failure.Index)
+	}
+	if len(tr.tasks) != 1 || task.Status != domain.StatusTodo {
+		t.Error("expected no operation to be applied")
+	}
+}
+
+func TestBatch_WIPLimit_RollsBack(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	laneByName(t, br, board.ID, domain.StatusInProgress).WIPLimit = 1
+
+	body := `{"operations":[
+		{"op":"create","task":{"title":"one","status":"IN_PROGRESS"}},
+		{"op":"create","task":{"title":"two","status":"IN_PROGRESS"}}
+	]}`
+	rr := postBatch(t, r, body)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(tr.tasks) != 0 {
+		t.Errorf("expected the batch to roll back, found %d tasks", len(tr.tasks))
+	}
+}
+
+func TestBatch_TaskFromOtherBoard_Returns404(t *testing.T) {
+	r, br, tr := newTestRouter()
+	seedBoard(br, testKey, false)
+	other := seedBoard(br, "bbbbbbbbbbbbbbbb", false)
+	foreign := seedTask(tr, other.ID)
+
+	rr := postBatch(t, r, `{"operations":[{"op":"delete","id":"`+foreign.ID.String()+`"}]}`)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rr.Code)
+	}
+	if foreign.ArchivedAt != nil {
+		t.Error("expected the foreign task to be untouched")
+	}
+}
+
+func TestBatch_BadRequests_Return400(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	id := seedTask(tr, board.ID).ID.String()
+	for name, body := range map[string]string{
+		"empty":          `{"operations":[]}`,
+		"unknown op":     `{"operations":[{"op":"rename","id":"` + id + `"}]}`,
+		"move no status": `{"operations":[{"op":"move","id":"` + id + `","task":{}}]}`,
+		"bad title":      `{"operations":[{"op":"create","task":{"title":" "}}]}`,
+	} {
+		if rr := postBatch(t, r, body); rr.Code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", name, rr.Code)
+		}
+	}
+}
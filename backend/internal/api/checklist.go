@@ -0,0 +1,228 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	maxChecklistItemsPerTask = 50
+	maxChecklistTextLength   = 500
+)
+
+type CreateChecklistItemReq struct {
+	Text     string `json:"text"`
+	Position *int   `json:"position,omitempty"`
+}
+
+type UpdateChecklistItemReq struct {
+	Text     *string `json:"text,omitempty"`
+	Done     *bool   `json:"done,omitempty"`
+	Position *int    `json:"position,omitempty"`
+}
+
+// validateChecklistText returns a user-facing error message, or "" if text is acceptable.
+func validateChecklistText(text string) string {
+	if strings.TrimSpace(text) == "" {
+		return "Checklist item text is required"
+	}
+	if len(text) > maxChecklistTextLength {
+		return fmt.Sprintf("Checklist item text must be %d characters or fewer", maxChecklistTextLength)
+	}
+	return ""
+}
+
+func checklistIDs(items []*domain.ChecklistItem) []uuid.UUID {
+	ids := make([]uuid.UUID, len(items))
+	for i, item := range items {
+		ids[i] = item.ID
+	}
+	return ids
+}
+
+func (r *Router) handleListChecklist(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	items, err := r.checklistRepo.ListByTaskID(req.Context(), task.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch checklist")
+		return
+	}
+	if items == nil {
+		items = []*domain.ChecklistItem{}
+	}
+	respondJSON(w, http.StatusOK, items)
+}
+
+func (r *Router) handleCreateChecklistItem(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	var reqBody CreateChecklistItemReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := validateChecklistText(reqBody.Text); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	items, err := r.checklistRepo.ListByTaskID(req.Context(), task.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch checklist")
+		return
+	}
+	if len(items) >= maxChecklistItemsPerTask {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Checklist limit reached (%d)", maxChecklistItemsPerTask))
+		return
+	}
+
+	item := &domain.ChecklistItem{
+		ID:        uuid.New(),
+		TaskID:    task.ID,
+		Text:      reqBody.Text,
+		Position:  len(items), // append to end
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	if err := r.checklistRepo.Create(req.Context(), item); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create checklist item")
+		return
+	}
+
+	if reqBody.Position != nil && *reqBody.Position < len(items) {
+		ids := moveID(checklistIDs(append(items, item)), len(items), *reqBody.Position)
+		if err := r.checklistRepo.Reorder(req.Context(), task.ID, ids); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to reorder checklist")
+			return
+		}
+		item.Position = indexOfID(ids, item.ID)
+	}
+
+	if err := r.touchTask(req, task); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, item)
+}
+
+func (r *Router) handleUpdateChecklistItem(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	itemID, err := uuid.Parse(chi.URLParam(req, "itemID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid checklist item ID format")
+		return
+	}
+
+	var reqBody UpdateChecklistItemReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	items, err := r.checklistRepo.ListByTaskID(req.Context(), task.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch checklist")
+		return
+	}
+	index := indexOfID(checklistIDs(items), itemID)
+	if index < 0 {
+		respondError(w, http.StatusNotFound, "Checklist item not found")
+		return
+	}
+	item := items[index]
+
+	if reqBody.Text != nil {
+		if msg := validateChecklistText(*reqBody.Text); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+		item.Text = *reqBody.Text
+	}
+	if reqBody.Done != nil {
+		item.Done = *reqBody.Done
+	}
+	item.UpdatedAt = time.Now()
+
+	if err := r.checklistRepo.Update(req.Context(), item); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update checklist item")
+		return
+	}
+
+	if reqBody.Position != nil && *reqBody.Position != index {
+		ids := moveID(checklistIDs(items), index, *reqBody.Position)
+		if err := r.checklistRepo.Reorder(req.Context(), task.ID, ids); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to reorder checklist")
+			return
+		}
+		item.Position = indexOfID(ids, item.ID)
+	}
+
+	if err := r.touchTask(req, task); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, item)
+}
+
+func (r *Router) handleDeleteChecklistItem(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	itemID, err := uuid.Parse(chi.URLParam(req, "itemID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid checklist item ID format")
+		return
+	}
+
+	item, err := r.checklistRepo.GetByID(req.Context(), itemID)
+	if err != nil || item.TaskID != task.ID {
+		respondError(w, http.StatusNotFound, "Checklist item not found")
+		return
+	}
+
+	if err := r.checklistRepo.Delete(req.Context(), item.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete checklist item")
+		return
+	}
+
+	// Close the gap left by the deleted item.
+	items, err := r.checklistRepo.ListByTaskID(req.Context(), task.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch checklist")
+		return
+	}
+	if err := r.checklistRepo.Reorder(req.Context(), task.ID, checklistIDs(items)); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reorder checklist")
+		return
+	}
+
+	if err := r.touchTask(req, task); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
@@ -0,0 +1,131 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func checklistPath(task *domain.Task) string {
+	return "/api/boards/" + testKey + "/tasks/" + task.ID.String() + "/checklist"
+}
+
+func addChecklistItem(t *testing.T, r *Router, task *domain.Task, body string) *domain.ChecklistItem {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, checklistPath(task), strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	item := &domain.ChecklistItem{}
+	if err := json.NewDecoder(rr.Body).Decode(item); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	return item
+}
+
+func TestCreateChecklistItem_EmptyText_Returns400(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	req := httptest.NewRequest(http.MethodPost, checklistPath(task), strings.NewReader(`{"text":"  "}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestCreateChecklistItem_WrongBoardKey_Returns403(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/0000000000000000/tasks/"+task.ID.String()+"/checklist", strings.NewReader(`{"text":"step"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rr.Code)
+	}
+}
+
+func TestChecklist_ReorderAndToggle(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	first := addChecklistItem(t, r, task, `{"text":"one"}`)
+	addChecklistItem(t, r, task, `{"text":"two"}`)
+	third := addChecklistItem(t, r, task, `{"text":"three","position":0}`)
+	if third.Position != 0 {
+		t.Errorf("expected inserted item at position 0, got %d", third.Position)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, checklistPath(task)+"/"+first.ID.String(), strings.NewReader(`{"done":true,"position":2}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	req = httptest.NewRequest(http.MethodGet, checklistPath(task), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var items []domain.ChecklistItem
+	if err := json.NewDecoder(rr.Body).Decode(&items); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	var texts []string
+	for _, item := range items {
+		texts = append(texts, item.Text)
+	}
+	if strings.Join(texts, ",") != "three,two,one" {
+		t.Errorf("unexpected order: %v", texts)
+	}
+	if !items[2].Done {
+		t.Error("expected moved item to be marked done")
+	}
+}
+
+func TestGetBoard_IncludesChecklistSummary(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	item := addChecklistItem(t, r, task, `{"text":"one"}`)
+	addChecklistItem(t, r, task, `{"text":"two"}`)
+
+	req := httptest.NewRequest(http.MethodPut, checklistPath(task)+"/"+item.ID.String(), strings.NewReader(`{"done":true}`))
+	r.ServeHTTP(httptest.NewRecorder(), req)
+
+	req = httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var resp BoardResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].Checklist == nil {
+		t.Fatalf("expected a checklist summary on the task")
+	}
+	if got := *resp.Tasks[0].Checklist; got.Done != 1 || got.Total != 2 {
+		t.Errorf("expected 1/2, got %d/%d", got.Done, got.Total)
+	}
+}
+
+func TestDeleteChecklistItem_OtherTask_Returns404(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	other := seedTask(tr, board.ID)
+	item := addChecklistItem(t, r, other, `{"text":"one"}`)
+
+	req := httptest.NewRequest(http.MethodDelete, checklistPath(task)+"/"+item.ID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rr.Code)
+	}
+}
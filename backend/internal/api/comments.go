@@ -0,0 +1,123 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	maxCommentsPerTask     = 200
+	maxCommentAuthorLength = 100
+	maxCommentBodyLength   = 5000
+)
+
+type CreateCommentReq struct {
+	Author string `json:"author"`
+	Body   string `json:"body"`
+}
+
+func (r *Router) handleListComments(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	comments, err := r.commentRepo.ListByTaskID(req.Context(), task.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch comments")
+		return
+	}
+	if comments == nil {
+		comments = []*domain.Comment{}
+	}
+	respondJSON(w, http.StatusOK, comments)
+}
+
+func (r *Router) handleCreateComment(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	var reqBody CreateCommentReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	author := strings.TrimSpace(reqBody.Author)
+	if author == "" {
+		respondError(w, http.StatusBadRequest, "Author is required")
+		return
+	}
+	if len(author) > maxCommentAuthorLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("Author must be %d characters or fewer", maxCommentAuthorLength))
+		return
+	}
+	if strings.TrimSpace(reqBody.Body) == "" {
+		respondError(w, http.StatusBadRequest, "Body is required")
+		return
+	}
+	if len(reqBody.Body) > maxCommentBodyLength {
+		respondError(w, http.StatusBadRequest, "Body must be 5,000 characters or fewer")
+		return
+	}
+
+	count, err := r.commentRepo.CountByTaskID(req.Context(), task.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to check comment limit")
+		return
+	}
+	if count >= maxCommentsPerTask {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Comment limit reached (%d)", maxCommentsPerTask))
+		return
+	}
+
+	comment := &domain.Comment{
+		ID:        uuid.New(),
+		TaskID:    task.ID,
+		Author:    author,
+		Body:      reqBody.Body,
+		CreatedAt: time.Now(),
+	}
+	if err := r.commentRepo.Create(req.Context(), comment); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create comment")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, comment)
+}
+
+func (r *Router) handleDeleteComment(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	commentID, err := uuid.Parse(chi.URLParam(req, "commentID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid comment ID format")
+		return
+	}
+
+	comment, err := r.commentRepo.GetByID(req.Context(), commentID)
+	if err != nil || comment.TaskID != task.ID {
+		respondError(w, http.StatusNotFound, "Comment not found")
+		return
+	}
+
+	if err := r.commentRepo.Delete(req.Context(), comment.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete comment")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
@@ -0,0 +1,100 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func commentsPath(key string, task *domain.Task) string {
+	return "/api/boards/" + key + "/tasks/" + task.ID.String() + "/comments"
+}
+
+func TestCreateComment_MissingAuthor_Returns400(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	req := httptest.NewRequest(http.MethodPost, commentsPath(testKey, task), strings.NewReader(`{"body":"hi"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestCreateComment_WrongBoardKey_Returns403(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	req := httptest.NewRequest(http.MethodPost, commentsPath("0000000000000000", task), strings.NewReader(`{"author":"a","body":"hi"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rr.Code)
+	}
+}
+
+func TestComments_CreateListDelete(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	task.Description = "original"
+
+	req := httptest.NewRequest(http.MethodPost, commentsPath(testKey, task), strings.NewReader(`{"author":"agent-1","body":"started"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var created domain.Comment
+	if err := json.NewDecoder(rr.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if tr.tasks[task.ID].Description != "original" {
+		t.Error("commenting must not touch the task description")
+	}
+
+	req = httptest.NewRequest(http.MethodGet, commentsPath(testKey, task), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var comments []domain.Comment
+	if err := json.NewDecoder(rr.Body).Decode(&comments); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(comments) != 1 || comments[0].Author != "agent-1" || comments[0].Body != "started" {
+		t.Fatalf("unexpected comments: %+v", comments)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, commentsPath(testKey, task)+"/"+created.ID.String(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rr.Code)
+	}
+}
+
+func TestDeleteComment_OtherTask_Returns404(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	other := seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodPost, commentsPath(testKey, other), strings.NewReader(`{"author":"a","body":"hi"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var created domain.Comment
+	if err := json.NewDecoder(rr.Body).Decode(&created); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+
+	req = httptest.NewRequest(http.MethodDelete, commentsPath(testKey, task)+"/"+created.ID.String(), nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,145 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+type AddDependencyReq struct {
+	BlockedBy uuid.UUID `json:"blocked_by"`
+}
+
+// attachDependencies fills in BlockedBy and Blocks on tasks from the board's edges.
+func attachDependencies(tasks []*domain.Task, deps []*domain.Dependency) {
+	byID := make(map[uuid.UUID]*domain.Task, len(tasks))
+	for _, t := range tasks {
+		byID[t.ID] = t
+	}
+	for _, d := range deps {
+		if t, ok := byID[d.TaskID]; ok {
+			t.BlockedBy = append(t.BlockedBy, d.BlockedByID)
+		}
+		if t, ok := byID[d.BlockedByID]; ok {
+			t.Blocks = append(t.Blocks, d.TaskID)
+		}
+	}
+}
+
+// blockedMessage explains a move into the final lane refused because of open blockers.
+func blockedMessage(open int) string {
+	return fmt.Sprintf("Task is blocked by %d open task(s)", open)
+}
+
+// openBlockers counts the tasks blocking task that are not yet in the board's
+// final lane. Archived blockers are not counted: an archived task is out of
+// play, so it must not hold up its dependents while hidden from the board.
+func (r *Router) openBlockers(req *http.Request, board *domain.Board, task *domain.Task, lanes []*domain.Lane) (int, error) {
+	deps, err := r.dependencyRepo.ListByBoardID(req.Context(), board.ID)
+	if err != nil {
+		return 0, err
+	}
+	done := finalLane(lanes)
+
+	open := 0
+	for _, d := range deps {
+		if d.TaskID != task.ID {
+			continue
+		}
+		blocker, err := r.taskRepo.GetByID(req.Context(), d.BlockedByID)
+		if err != nil {
+			return 0, err
+		}
+		if blocker.Status != done && blocker.ArchivedAt == nil {
+			open++
+		}
+	}
+	return open, nil
+}
+
+func (r *Router) handleAddDependency(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	var reqBody AddDependencyReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if reqBody.BlockedBy == uuid.Nil {
+		respondError(w, http.StatusBadRequest, "blocked_by is required")
+		return
+	}
+	if reqBody.BlockedBy == task.ID {
+		respondError(w, http.StatusBadRequest, "A task cannot block itself")
+		return
+	}
+
+	blocker, err := r.taskRepo.GetByID(req.Context(), reqBody.BlockedBy)
+	if err != nil || blocker.BoardID != board.ID {
+		respondError(w, http.StatusBadRequest, "blocked_by must be a task on the same board")
+		return
+	}
+
+	dep := &domain.Dependency{TaskID: task.ID, BlockedByID: blocker.ID}
+	if err := r.dependencyRepo.Create(req.Context(), board.ID, dep); err != nil {
+		if errors.Is(err, domain.ErrDependencyCycle) {
+			respondError(w, http.StatusConflict, "Dependency would create a cycle")
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to create dependency")
+		return
+	}
+
+	// Both ends change: task gains a blocked_by entry and blocker a blocks entry.
+	if err := r.touchTask(req, task); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		return
+	}
+	if err := r.touchTask(req, blocker); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, dep)
+}
+
+func (r *Router) handleRemoveDependency(w http.ResponseWriter, req *http.Request) {
+	task, _ := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+
+	blockerID, err := uuid.Parse(chi.URLParam(req, "blockerID"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid task ID format")
+		return
+	}
+
+	if err := r.dependencyRepo.Delete(req.Context(), task.ID, blockerID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete dependency")
+		return
+	}
+
+	if err := r.touchTask(req, task); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		return
+	}
+	// The blocker may already have been purged; it has nothing to touch then.
+	if blocker, err := r.taskRepo.GetByID(req.Context(), blockerID); err == nil {
+		if err := r.touchTask(req, blocker); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to update task")
+			return
+		}
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
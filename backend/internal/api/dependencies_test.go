@@ -0,0 +1,160 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func linkTasks(r *Router, task, blocker *domain.Task) *httptest.ResponseRecorder {
+	body := `{"blocked_by":"` + blocker.ID.String() + `"}`
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks/"+task.ID.String()+"/dependencies", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestAddDependency_Cycle_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	a := seedTask(tr, board.ID)
+	b := seedTask(tr, board.ID)
+	c := seedTask(tr, board.ID)
+
+	if rr := linkTasks(r, a, b); rr.Code != http.StatusCreated {
+		t.Fatalf("a←b: expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := linkTasks(r, b, c); rr.Code != http.StatusCreated {
+		t.Fatalf("b←c: expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if rr := linkTasks(r, c, a); rr.Code != http.StatusConflict {
+		t.Errorf("c←a: expected 409 for cycle, got %d", rr.Code)
+	}
+}
+
+func TestAddDependency_Self_Returns400(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	a := seedTask(tr, board.ID)
+	if rr := linkTasks(r, a, a); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestAddDependency_OtherBoard_Returns400(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	other := seedBoard(br, "bbbbbbbbbbbbbbbb", false)
+	a := seedTask(tr, board.ID)
+	foreign := seedTask(tr, other.ID)
+	if rr := linkTasks(r, a, foreign); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestGetBoard_ExposesDependencies(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	a := seedTask(tr, board.ID)
+	b := seedTask(tr, board.ID)
+	linkTasks(r, a, b)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var resp BoardResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	for _, task := range resp.Tasks {
+		switch task.ID {
+		case a.ID:
+			if len(task.BlockedBy) != 1 || task.BlockedBy[0] != b.ID {
+				t.Errorf("expected a.blocked_by = [b], got %v", task.BlockedBy)
+			}
+		case b.ID:
+			if len(task.Blocks) != 1 || task.Blocks[0] != a.ID {
+				t.Errorf("expected b.blocks = [a], got %v", task.Blocks)
+			}
+		}
+	}
+}
+
+func TestUpdateTask_EnforcedDependencies(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	board.EnforceDependencies = true
+	a := seedTask(tr, board.ID)
+	b := seedTask(tr, board.ID)
+	linkTasks(r, a, b)
+
+	move := func(task *domain.Task) int {
+		req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), strings.NewReader(`{"status":"DONE"}`))
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := move(a); code != http.StatusConflict {
+		t.Errorf("expected 409 while blocker is open, got %d", code)
+	}
+	if code := move(b); code != http.StatusOK {
+		t.Fatalf("expected 200 for the blocker, got %d", code)
+	}
+	if code := move(a); code != http.StatusOK {
+		t.Errorf("expected 200 once blocker is done, got %d", code)
+	}
+}
+
+func TestUpdateTask_ArchivedBlockerDoesNotBlock(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	board.EnforceDependencies = true
+	a := seedTask(tr, board.ID)
+	b := seedTask(tr, board.ID)
+	linkTasks(r, a, b)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, taskPath(b), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected the blocker archived, got %d", rr.Code)
+	}
+
+	req := httptest.NewRequest(http.MethodPut, taskPath(a), strings.NewReader(`{"status":"DONE"}`))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 once the blocker is archived, got %d", rr.Code)
+	}
+
+	// The same holds inside a batch.
+	c := seedTask(tr, board.ID)
+	d := seedTask(tr, board.ID)
+	linkTasks(r, c, d)
+	body := `{"operations":[{"op":"delete","id":"` + d.ID.String() + `"},` +
+		`{"op":"update","id":"` + c.ID.String() + `","task":{"status":"DONE"}}]}`
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/batch", strings.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected the batch to succeed, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestUpdateTask_DependenciesNotEnforcedByDefault(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	a := seedTask(tr, board.ID)
+	b := seedTask(tr, board.ID)
+	linkTasks(r, a, b)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+a.ID.String(), strings.NewReader(`{"status":"DONE"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200, got %d", rr.Code)
+	}
+}
@@ -1,9 +1,14 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
@@ -17,32 +22,286 @@ import (
 // boardKeyRe matches valid board key strings: 8–64 lowercase hex characters.
 var boardKeyRe = regexp.MustCompile(`^[0-9a-f]{8,64}$`)
 
-// isValidStatus reports whether s is a valid TaskStatus value.
-func isValidStatus(s domain.TaskStatus) bool {
-	return s == domain.StatusTodo || s == domain.StatusInProgress || s == domain.StatusDone
-}
-
 // DTOs
 type CreateBoardReq struct {
-	Title string `json:"title"`
+	Title               string              `json:"title"`
+	Lanes               []domain.TaskStatus `json:"lanes,omitempty"`
+	EnforceDependencies bool                `json:"enforce_dependencies"`
+	// TTLSeconds defaults to a week, or the server maximum if that is less.
+	TTLSeconds    *int `json:"ttl_seconds,omitempty"`
+	SlidingExpiry bool `json:"sliding_expiry"`
 }
 
+type UpdateBoardReq struct {
+	Title               *string `json:"title,omitempty"`
+	EnforceDependencies *bool   `json:"enforce_dependencies,omitempty"`
+	SlidingExpiry       *bool   `json:"sliding_expiry,omitempty"`
+}
+
+// BoardResponse is a board with one page of its tasks. NextCursor is set
+// when more tasks follow.
 type BoardResponse struct {
 	*domain.Board
-	Tasks []*domain.Task `json:"tasks"`
+	Tasks      []*domain.Task `json:"tasks"`
+	Assignees  []string       `json:"assignees"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+	// Access is what the key used can do: edit, or only view.
+	Access domain.Access `json:"access"`
 }
 
 type CreateTaskReq struct {
-	Title       string            `json:"title"`
-	Description string            `json:"description"`
-	Status      domain.TaskStatus `json:"status"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Status      domain.TaskStatus   `json:"status"`
+	Labels      []string            `json:"labels,omitempty"`
+	Assignee    string              `json:"assignee,omitempty"`
+	DueAt       string              `json:"due_at,omitempty"`
+	Priority    domain.TaskPriority `json:"priority,omitempty"`
 }
 
 type UpdateTaskReq struct {
-	Title       *string            `json:"title,omitempty"`
-	Description *string            `json:"description,omitempty"`
-	Status      *domain.TaskStatus `json:"status,omitempty"`
-	Position    *int               `json:"position,omitempty"`
+	Title       *string              `json:"title,omitempty"`
+	Description *string              `json:"description,omitempty"`
+	Status      *domain.TaskStatus   `json:"status,omitempty"`
+	Position    *int                 `json:"position,omitempty"`
+	Labels      *[]string            `json:"labels,omitempty"`
+	Assignee    *string              `json:"assignee,omitempty"`
+	DueAt       *string              `json:"due_at,omitempty"`
+	Priority    *domain.TaskPriority `json:"priority,omitempty"`
+}
+
+const (
+	maxTasksPerBoard  = 100
+	maxLabelsPerTask  = 20
+	maxLabelLength    = 50
+	maxAssigneeLength = 100
+)
+
+// endOfLane is a position past the end of any lane. The repository clamps
+// positions to the lane's length, so it places a task last.
+const endOfLane = maxTasksPerBoard
+
+// archivedTaskMessage rejects edits to archived tasks.
+const archivedTaskMessage = "Task is archived — restore it before editing"
+
+// taskChangedMessage rejects a write based on an outdated version of a task.
+const taskChangedMessage = "Task has been changed by someone else — fetch it again and retry"
+
+// maxTouchAttempts bounds how often touchTask retries after a version conflict.
+const maxTouchAttempts = 3
+
+// normalizeLabels trims and de-duplicates labels, returning a user-facing
+// error message if any label is invalid.
+func normalizeLabels(labels []string) ([]string, string) {
+	if len(labels) > maxLabelsPerTask {
+		return nil, fmt.Sprintf("A task can have at most %d labels", maxLabelsPerTask)
+	}
+	out := make([]string, 0, len(labels))
+	seen := make(map[string]bool, len(labels))
+	for _, label := range labels {
+		label = strings.TrimSpace(label)
+		if label == "" {
+			return nil, "Labels cannot be empty"
+		}
+		if len(label) > maxLabelLength {
+			return nil, fmt.Sprintf("Labels must be %d characters or fewer", maxLabelLength)
+		}
+		if !seen[label] {
+			seen[label] = true
+			out = append(out, label)
+		}
+	}
+	sort.Strings(out)
+	return out, ""
+}
+
+// labelFilter trims and de-duplicates label query values, dropping empty ones,
+// so that they compare equal to stored labels.
+func labelFilter(values []string) []string {
+	var labels []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v != "" && !slices.Contains(labels, v) {
+			labels = append(labels, v)
+		}
+	}
+	return labels
+}
+
+// parseDueDate accepts an RFC 3339 timestamp or a YYYY-MM-DD date, which is
+// taken as the end of that day in UTC. An empty string clears the due date.
+func parseDueDate(s string) (*time.Time, string) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil, ""
+	}
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return &t, ""
+	}
+	if d, err := time.Parse("2006-01-02", s); err == nil {
+		t := d.Add(24*time.Hour - time.Second)
+		return &t, ""
+	}
+	return nil, "Due date must be an RFC 3339 timestamp or a YYYY-MM-DD date"
+}
+
+// validatePriority returns a user-facing error message, or "" if p is a known priority.
+func validatePriority(p domain.TaskPriority) string {
+	if p.Rank() < 0 {
+		return "Priority must be one of: LOW, MEDIUM, HIGH, URGENT"
+	}
+	return ""
+}
+
+// normalizeAssignee trims an assignee name, returning a user-facing error
+// message if it is too long. An empty result means unassigned.
+func normalizeAssignee(assignee string) (string, string) {
+	assignee = strings.TrimSpace(assignee)
+	if len(assignee) > maxAssigneeLength {
+		return "", fmt.Sprintf("Assignee must be %d characters or fewer", maxAssigneeLength)
+	}
+	return assignee, ""
+}
+
+// stripControl removes C0 control characters other than tab, newline and
+// carriage return. Search highlighting uses two of them as markers, so they
+// must never reach stored task text.
+func stripControl(s string) string {
+	return strings.Map(func(c rune) rune {
+		if c < 0x20 && c != '\t' && c != '\n' && c != '\r' {
+			return -1
+		}
+		return c
+	}, s)
+}
+
+// newTask validates a create request and builds the task it describes. It
+// returns a user-facing error message, or "" on success. A missing status
+// puts the task in the board's first lane; the repository appends it to the
+// end of that lane.
+func newTask(boardID uuid.UUID, reqBody CreateTaskReq, lanes []*domain.Lane) (*domain.Task, string) {
+	reqBody.Title = stripControl(reqBody.Title)
+	reqBody.Description = stripControl(reqBody.Description)
+	if strings.TrimSpace(reqBody.Title) == "" {
+		return nil, "Title is required"
+	}
+	if len(reqBody.Title) > 255 {
+		return nil, "Title must be 255 characters or fewer"
+	}
+	if len(reqBody.Description) > 10000 {
+		return nil, "Description must be 10,000 characters or fewer"
+	}
+
+	labels, msg := normalizeLabels(reqBody.Labels)
+	if msg != "" {
+		return nil, msg
+	}
+	assignee, msg := normalizeAssignee(reqBody.Assignee)
+	if msg != "" {
+		return nil, msg
+	}
+	dueAt, msg := parseDueDate(reqBody.DueAt)
+	if msg != "" {
+		return nil, msg
+	}
+	if msg := validatePriority(reqBody.Priority); msg != "" {
+		return nil, msg
+	}
+
+	if reqBody.Status == "" {
+		// New tasks land in the board's first lane by default.
+		if len(lanes) > 0 {
+			reqBody.Status = lanes[0].Name
+		} else {
+			reqBody.Status = domain.StatusTodo
+		}
+	} else if findLaneByName(lanes, reqBody.Status) == nil {
+		return nil, invalidStatusMessage(lanes)
+	}
+
+	now := time.Now()
+	return &domain.Task{
+		ID:          uuid.New(),
+		BoardID:     boardID,
+		Title:       reqBody.Title,
+		Description: reqBody.Description,
+		Status:      reqBody.Status,
+		Labels:      labels,
+		Assignee:    assignee,
+		DueAt:       dueAt,
+		Priority:    reqBody.Priority,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, ""
+}
+
+// applyTaskUpdate copies the fields present in reqBody onto task, validating
+// a new status against lanes. It returns a user-facing error message, or ""
+// on success; task may be partially modified when a field is rejected.
+func applyTaskUpdate(task *domain.Task, reqBody UpdateTaskReq, lanes []*domain.Lane) string {
+	// Only update fields that are provided (partial update support)
+	if reqBody.Title != nil {
+		title := stripControl(*reqBody.Title)
+		if strings.TrimSpace(title) == "" {
+			return "Title cannot be empty"
+		}
+		if len(title) > 255 {
+			return "Title must be 255 characters or fewer"
+		}
+		task.Title = title
+	}
+	if reqBody.Description != nil {
+		description := stripControl(*reqBody.Description)
+		if len(description) > 10000 {
+			return "Description must be 10,000 characters or fewer"
+		}
+		task.Description = description
+	}
+	if reqBody.Status != nil {
+		if findLaneByName(lanes, *reqBody.Status) == nil {
+			return invalidStatusMessage(lanes)
+		}
+		if *reqBody.Status != task.Status && reqBody.Position == nil {
+			// A task changing lanes without a position joins the end.
+			task.Position = endOfLane
+		}
+		task.Status = *reqBody.Status
+	}
+	if reqBody.Position != nil {
+		if *reqBody.Position < 0 {
+			return "Position must not be negative"
+		}
+		task.Position = *reqBody.Position
+	}
+	if reqBody.Labels != nil {
+		labels, msg := normalizeLabels(*reqBody.Labels)
+		if msg != "" {
+			return msg
+		}
+		task.Labels = labels
+	}
+	if reqBody.Assignee != nil {
+		assignee, msg := normalizeAssignee(*reqBody.Assignee)
+		if msg != "" {
+			return msg
+		}
+		task.Assignee = assignee
+	}
+	if reqBody.DueAt != nil {
+		dueAt, msg := parseDueDate(*reqBody.DueAt)
+		if msg != "" {
+			return msg
+		}
+		task.DueAt = dueAt
+	}
+	if reqBody.Priority != nil {
+		if msg := validatePriority(*reqBody.Priority); msg != "" {
+			return msg
+		}
+		task.Priority = *reqBody.Priority
+	}
+	task.UpdatedAt = time.Now()
+	return ""
 }
 
 // Handlers
@@ -70,12 +329,43 @@ func (r *Router) handleCreateBoard(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
+	laneNames := reqBody.Lanes
+	if len(laneNames) == 0 {
+		laneNames = domain.DefaultLanes
+	}
+	if msg := validateLaneNames(laneNames); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	ttlSeconds := int(r.defaultTTL().Seconds())
+	if reqBody.TTLSeconds != nil {
+		ttlSeconds = *reqBody.TTLSeconds
+		if msg := r.validateTTL(ttlSeconds); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+	}
+
+	now := time.Now()
 	board := &domain.Board{
-		ID:        uuid.New(),
-		Key:       generateBoardKey(),
-		Title:     reqBody.Title,
-		CreatedAt: time.Now(),
-		ExpiresAt: time.Now().AddDate(0, 0, 7), // 7 days expiry
+		ID:                  uuid.New(),
+		Key:                 generateBoardKey(),
+		Title:               reqBody.Title,
+		CreatedAt:           now,
+		UpdatedAt:           now,
+		ExpiresAt:           now.Add(time.Duration(ttlSeconds) * time.Second),
+		EnforceDependencies: reqBody.EnforceDependencies,
+		TTLSeconds:          ttlSeconds,
+		SlidingExpiry:       reqBody.SlidingExpiry,
+	}
+	for i, name := range laneNames {
+		board.Lanes = append(board.Lanes, &domain.Lane{
+			ID:       uuid.New(),
+			BoardID:  board.ID,
+			Name:     name,
+			Position: i,
+		})
 	}
 
 	if err := r.boardRepo.Create(req.Context(), board); err != nil {
@@ -87,42 +377,122 @@ func (r *Router) handleCreateBoard(w http.ResponseWriter, req *http.Request) {
 }
 
 func (r *Router) handleGetBoard(w http.ResponseWriter, req *http.Request) {
-	key := chi.URLParam(req, "key")
-	if key == "" {
-		respondError(w, http.StatusBadRequest, "Board key is required")
+	board, access := r.boardAccessFromPath(w, req)
+	if board == nil {
 		return
 	}
 
-	board, err := r.boardRepo.GetByKey(req.Context(), key)
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
 	if err != nil {
-		// Better error handling for Not Found in production
-		respondError(w, http.StatusNotFound, "Board not found")
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
 		return
 	}
+	board.Lanes = lanes
 
-	// Check expiry
-	if time.Now().After(board.ExpiresAt) {
-		respondError(w, http.StatusGone, "Board has expired")
+	filter := domain.TaskFilter{
+		Labels: labelFilter(req.URL.Query()["label"]),
+	}
+	if values, ok := req.URL.Query()["assignee"]; ok {
+		// ?assignee= with no value selects unassigned tasks.
+		assignee := strings.TrimSpace(values[0])
+		filter.Assignee = &assignee
+	}
+	switch req.URL.Query().Get("overdue") {
+	case "", "false":
+	case "true":
+		filter.Overdue = true
+	default:
+		respondError(w, http.StatusBadRequest, "overdue must be true or false")
+		return
+	}
+	for _, include := range req.URL.Query()["include"] {
+		if include != "archived" {
+			respondError(w, http.StatusBadRequest, "include must be archived")
+			return
+		}
+		filter.IncludeArchived = true
+	}
+	switch sortBy := domain.TaskSort(req.URL.Query().Get("sort")); sortBy {
+	case domain.SortByLane, "lane":
+	case domain.SortByPriority:
+		filter.Sort = sortBy
+	default:
+		respondError(w, http.StatusBadRequest, "sort must be lane or priority")
+		return
+	}
+	for _, status := range req.URL.Query()["status"] {
+		if findLaneByName(lanes, domain.TaskStatus(status)) == nil {
+			respondError(w, http.StatusBadRequest, invalidStatusMessage(lanes))
+			return
+		}
+		filter.Statuses = append(filter.Statuses, domain.TaskStatus(status))
+	}
+	if msg := parsePage(req.URL.Query(), &filter); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	fields, msg := parseFields(req.URL.Query())
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
 		return
 	}
 
-	tasks, err := r.taskRepo.GetByBoardID(req.Context(), board.ID)
+	// Fetch one task past the page to learn whether another page follows.
+	limit := filter.Limit
+	if limit > 0 {
+		filter.Limit++
+	}
+	tasks, err := r.taskRepo.GetByBoardID(req.Context(), board.ID, filter)
 	if err != nil {
 		respondError(w, http.StatusInternalServerError, "Failed to fetch tasks")
 		return
 	}
+	var nextCursor string
+	if limit > 0 && len(tasks) > limit {
+		tasks = tasks[:limit]
+		nextCursor = encodeCursor(filter.Sort, domain.NewTaskCursor(tasks[limit-1], lanes))
+	}
+
+	summaries, err := r.checklistRepo.SummarizeByBoardID(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch checklists")
+		return
+	}
+	for _, task := range tasks {
+		if s, ok := summaries[task.ID]; ok {
+			task.Checklist = &s
+		}
+	}
+
+	deps, err := r.dependencyRepo.ListByBoardID(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch dependencies")
+		return
+	}
+	attachDependencies(tasks, deps)
 
 	// Make sure tasks is not nil for JSON response even if empty
 	if tasks == nil {
 		tasks = []*domain.Task{}
 	}
 
-	// Generate ETag from board and task timestamps
+	assignees, err := r.taskRepo.ListAssignees(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch assignees")
+		return
+	}
+	if assignees == nil {
+		assignees = []string{}
+	}
+
+	// Generate ETag from board and task timestamps, and from everything else
+	// that shapes the page: its parameters, the next cursor and the assignees.
 	taskTimes := make([]time.Time, len(tasks))
 	for i, task := range tasks {
 		taskTimes[i] = task.UpdatedAt
 	}
-	etag := utils.GenerateETag(board.CreatedAt, taskTimes)
+	etagParts := append([]string{string(access), req.URL.Query().Encode(), nextCursor}, assignees...)
+	etag := utils.GenerateETag(board.UpdatedAt, taskTimes, etagParts...)
 
 	// Check If-None-Match header
 	ifNoneMatch := req.Header.Get("If-None-Match")
@@ -135,48 +505,83 @@ func (r *Router) handleGetBoard(w http.ResponseWriter, req *http.Request) {
 
 	// Set ETag header and return data
 	w.Header().Set("ETag", etag)
-	respondJSON(w, http.StatusOK, BoardResponse{
-		Board: board,
-		Tasks: tasks,
-	})
-}
-
-func (r *Router) handleDeleteBoard(w http.ResponseWriter, req *http.Request) {
-	key := chi.URLParam(req, "key")
-	if err := r.boardRepo.DeleteByKey(req.Context(), key); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete board")
+	resp := BoardResponse{
+		Board:      board,
+		Tasks:      tasks,
+		Assignees:  assignees,
+		NextCursor: nextCursor,
+		Access:     access,
+	}
+	if fields == nil {
+		respondJSON(w, http.StatusOK, resp)
 		return
 	}
-	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+	respondJSON(w, http.StatusOK, struct {
+		BoardResponse
+		Tasks []map[string]json.RawMessage `json:"tasks"`
+	}{resp, selectFields(tasks, fields)})
 }
 
-func (r *Router) handleCreateTask(w http.ResponseWriter, req *http.Request) {
-	key := chi.URLParam(req, "key")
-	if !boardKeyRe.MatchString(key) {
-		respondError(w, http.StatusBadRequest, "Invalid board key format")
+func (r *Router) handleUpdateBoard(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
 		return
 	}
 
-	board, err := r.boardRepo.GetByKey(req.Context(), key)
-	if err != nil {
-		respondError(w, http.StatusNotFound, "Board not found")
+	var reqBody UpdateBoardReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
 		return
 	}
 
-	// Check expiry
-	if time.Now().After(board.ExpiresAt) {
-		respondError(w, http.StatusGone, "Board has expired")
+	if reqBody.Title != nil {
+		if strings.TrimSpace(*reqBody.Title) == "" {
+			respondError(w, http.StatusBadRequest, "Title cannot be empty")
+			return
+		}
+		if len(*reqBody.Title) > 255 {
+			respondError(w, http.StatusBadRequest, "Title must be 255 characters or fewer")
+			return
+		}
+		board.Title = *reqBody.Title
+	}
+	if reqBody.EnforceDependencies != nil {
+		board.EnforceDependencies = *reqBody.EnforceDependencies
+	}
+	if reqBody.SlidingExpiry != nil {
+		board.SlidingExpiry = *reqBody.SlidingExpiry
+	}
+	board.UpdatedAt = time.Now()
+
+	if err := r.boardRepo.Update(req.Context(), board); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update board")
 		return
 	}
+	r.publish(board.ID, eventBoardUpdated, board)
 
-	// Check limits
-	count, err := r.taskRepo.CountByBoardID(req.Context(), board.ID)
-	if err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to check task limit")
+	respondJSON(w, http.StatusOK, board)
+}
+
+func (r *Router) handleDeleteBoard(w http.ResponseWriter, req *http.Request) {
+	key := chi.URLParam(req, "key")
+	board, access, lookupErr := r.boardFromKey(req.Context(), key)
+	if lookupErr == nil && !allowAccess(w, req, access) {
 		return
 	}
-	if count >= 100 {
-		respondError(w, http.StatusUnprocessableEntity, "Task limit reached (100)")
+	if err := r.boardRepo.DeleteByKey(req.Context(), key); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete board")
+		return
+	}
+	if lookupErr == nil {
+		r.publish(board.ID, eventBoardDeleted, BoardDeletedEvent{})
+		r.events.Close(board.ID)
+	}
+	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
+
+func (r *Router) handleCreateTask(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
 		return
 	}
 
@@ -186,76 +591,98 @@ func (r *Router) handleCreateTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	if strings.TrimSpace(reqBody.Title) == "" {
-		respondError(w, http.StatusBadRequest, "Title is required")
+	task, status, msg := r.createTask(req, board, reqBody)
+	if msg != "" {
+		respondError(w, status, msg)
 		return
 	}
-	if len(reqBody.Title) > 255 {
-		respondError(w, http.StatusBadRequest, "Title must be 255 characters or fewer")
-		return
+	respondTask(w, http.StatusCreated, task)
+}
+
+// createTask validates and stores a new task at the end of its lane, then
+// records and publishes it. On failure it returns the HTTP status and message
+// to report; it is shared by handleCreateTask and the board socket.
+func (r *Router) createTask(req *http.Request, board *domain.Board, reqBody CreateTaskReq) (*domain.Task, int, string) {
+	count, err := r.taskRepo.CountByBoardID(req.Context(), board.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, "Failed to check task limit"
 	}
-	if len(reqBody.Description) > 10000 {
-		respondError(w, http.StatusBadRequest, "Description must be 10,000 characters or fewer")
-		return
+	if count >= maxTasksPerBoard {
+		return nil, http.StatusUnprocessableEntity, fmt.Sprintf("Task limit reached (%d)", maxTasksPerBoard)
 	}
 
-	if reqBody.Status == "" {
-		reqBody.Status = domain.StatusTodo
-	} else if !isValidStatus(reqBody.Status) {
-		respondError(w, http.StatusBadRequest, "Status must be one of: TODO, IN_PROGRESS, DONE")
-		return
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		return nil, http.StatusInternalServerError, "Failed to fetch lanes"
 	}
 
-	task := &domain.Task{
-		ID:          uuid.New(),
-		BoardID:     board.ID,
-		Title:       reqBody.Title,
-		Description: reqBody.Description,
-		Status:      reqBody.Status,
-		Position:    count, // append to end
-		CreatedAt:   time.Now(),
-		UpdatedAt:   time.Now(),
+	task, msg := newTask(board.ID, reqBody, lanes)
+	if msg != "" {
+		return nil, http.StatusBadRequest, msg
 	}
 
 	if err := r.taskRepo.Create(req.Context(), task); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to create task")
-		return
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return nil, http.StatusConflict, wipLimitMessage(task.Status)
+		}
+		return nil, http.StatusInternalServerError, "Failed to create task"
 	}
-
-	respondJSON(w, http.StatusCreated, task)
+	r.recordActivity(req, board.ID, task.ID, domain.ActivityTaskCreated, diffTask(nil, task))
+	r.publish(board.ID, eventTaskCreated, task)
+	return task, 0, ""
 }
 
-func (r *Router) handleUpdateTask(w http.ResponseWriter, req *http.Request) {
-	idStr := chi.URLParam(req, "id")
-	id, err := uuid.Parse(idStr)
-	if err != nil {
-		respondError(w, http.StatusBadRequest, "Invalid task ID format")
+// handleGetTask returns a single task with its checklist summary and
+// dependencies, as it appears in the board view. Its ETag is derived from the
+// task's version and can be sent back in If-Match.
+func (r *Router) handleGetTask(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
 		return
 	}
 
-	// Board-ownership verification: board key is in the URL path.
-	boardKey := chi.URLParam(req, "key")
-	if !boardKeyRe.MatchString(boardKey) {
-		respondError(w, http.StatusBadRequest, "Invalid board key format")
+	etag := utils.VersionETag(task.Version)
+	if ifNoneMatch := req.Header.Get("If-None-Match"); ifNoneMatch != "" && ifNoneMatch == etag {
+		w.Header().Set("ETag", etag)
+		w.WriteHeader(http.StatusNotModified)
 		return
 	}
 
-	task, err := r.taskRepo.GetByID(req.Context(), id)
+	items, err := r.checklistRepo.ListByTaskID(req.Context(), task.ID)
 	if err != nil {
-		// Return 403 to avoid confirming whether the task exists.
-		respondError(w, http.StatusForbidden, "Forbidden")
+		respondError(w, http.StatusInternalServerError, "Failed to fetch checklist")
 		return
 	}
+	if len(items) > 0 {
+		summary := domain.ChecklistSummary{Total: len(items)}
+		for _, item := range items {
+			if item.Done {
+				summary.Done++
+			}
+		}
+		task.Checklist = &summary
+	}
 
-	board, err := r.boardRepo.GetByID(req.Context(), task.BoardID)
-	if err != nil || board.Key != boardKey {
-		respondError(w, http.StatusForbidden, "Forbidden")
+	deps, err := r.dependencyRepo.ListByBoardID(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch dependencies")
 		return
 	}
+	attachDependencies([]*domain.Task{task}, deps)
 
-	// Check expiry
-	if time.Now().After(board.ExpiresAt) {
-		respondError(w, http.StatusGone, "Board has expired")
+	respondTask(w, http.StatusOK, task)
+}
+
+func (r *Router) handleUpdateTask(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+	if task.ArchivedAt != nil {
+		respondError(w, http.StatusConflict, archivedTaskMessage)
+		return
+	}
+	if !checkIfMatch(w, req, task) {
 		return
 	}
 
@@ -265,83 +692,283 @@ func (r *Router) handleUpdateTask(w http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	// Only update fields that are provided (partial update support)
-	if reqBody.Title != nil {
-		if strings.TrimSpace(*reqBody.Title) == "" {
-			respondError(w, http.StatusBadRequest, "Title cannot be empty")
-			return
+	if status, msg := r.updateTask(req, board, task, reqBody, nil); msg != "" {
+		respondError(w, status, msg)
+		return
+	}
+	respondTask(w, http.StatusOK, task)
+}
+
+// updateTask applies reqBody to task and stores it, enforcing lanes, WIP
+// limits and dependencies, then records and publishes the change. A non-nil
+// neighbour places the task next to it instead of at its position. On
+// failure it returns the HTTP status and message to report; it is shared by
+// handleUpdateTask, handleMoveTask and the board socket.
+func (r *Router) updateTask(req *http.Request, board *domain.Board, task *domain.Task, reqBody UpdateTaskReq, neighbour *domain.Neighbour) (int, string) {
+	if task.ArchivedAt != nil {
+		return http.StatusConflict, archivedTaskMessage
+	}
+	before := *task
+
+	var lanes []*domain.Lane
+	if reqBody.Status != nil {
+		var err error
+		lanes, err = r.boardRepo.ListLanes(req.Context(), board.ID)
+		if err != nil {
+			return http.StatusInternalServerError, "Failed to fetch lanes"
 		}
-		if len(*reqBody.Title) > 255 {
-			respondError(w, http.StatusBadRequest, "Title must be 255 characters or fewer")
-			return
+	}
+	if msg := applyTaskUpdate(task, reqBody, lanes); msg != "" {
+		return http.StatusBadRequest, msg
+	}
+	if board.EnforceDependencies && task.Status != before.Status && task.Status == finalLane(lanes) {
+		open, err := r.openBlockers(req, board, task, lanes)
+		if err != nil {
+			return http.StatusInternalServerError, "Failed to check dependencies"
+		}
+		if open > 0 {
+			return http.StatusConflict, blockedMessage(open)
 		}
-		task.Title = *reqBody.Title
 	}
-	if reqBody.Description != nil {
-		if len(*reqBody.Description) > 10000 {
-			respondError(w, http.StatusBadRequest, "Description must be 10,000 characters or fewer")
-			return
+
+	var err error
+	if neighbour != nil {
+		err = r.taskRepo.Move(req.Context(), task, *neighbour)
+	} else {
+		err = r.taskRepo.Update(req.Context(), task)
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrWIPLimitReached) {
+			return http.StatusConflict, wipLimitMessage(task.Status)
+		}
+		if errors.Is(err, domain.ErrVersionConflict) {
+			return versionConflict(req)
 		}
-		task.Description = *reqBody.Description
+		if errors.Is(err, domain.ErrNeighbourNotInLane) {
+			return http.StatusConflict, neighbourMessage(task.Status)
+		}
+		return http.StatusInternalServerError, "Failed to update task"
 	}
-	if reqBody.Status != nil {
-		if !isValidStatus(*reqBody.Status) {
-			respondError(w, http.StatusBadRequest, "Status must be one of: TODO, IN_PROGRESS, DONE")
-			return
+	if changes := diffTask(&before, task); len(changes) > 0 {
+		action := domain.ActivityTaskUpdated
+		if before.Status != task.Status {
+			action = domain.ActivityTaskMoved
 		}
-		task.Status = *reqBody.Status
+		r.recordActivity(req, board.ID, task.ID, action, changes)
 	}
-	if reqBody.Position != nil {
-		task.Position = *reqBody.Position
+	r.publish(board.ID, eventTaskUpdated, task)
+	return 0, ""
+}
+
+// handleDeleteTask archives the task. Archived tasks can be restored or
+// purged permanently; see archive.go.
+func (r *Router) handleDeleteTask(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+	if task.ArchivedAt != nil {
+		respondError(w, http.StatusConflict, "Task is already archived")
+		return
+	}
+	if !checkIfMatch(w, req, task) {
+		return
 	}
-	task.UpdatedAt = time.Now()
 
+	now := time.Now()
+	task.ArchivedAt = &now
+	task.UpdatedAt = now
 	if err := r.taskRepo.Update(req.Context(), task); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to update task")
+		if errors.Is(err, domain.ErrVersionConflict) {
+			status, msg := versionConflict(req)
+			respondError(w, status, msg)
+			return
+		}
+		respondError(w, http.StatusInternalServerError, "Failed to archive task")
 		return
 	}
+	r.recordActivity(req, board.ID, task.ID, domain.ActivityTaskArchived, diffTask(task, nil))
+	r.publish(board.ID, eventTaskDeleted, TaskDeletedEvent{ID: task.ID, Archived: true})
 
-	respondJSON(w, http.StatusOK, task)
+	respondJSON(w, http.StatusOK, map[string]string{"message": "archived"})
 }
 
-func (r *Router) handleDeleteTask(w http.ResponseWriter, req *http.Request) {
-	idStr := chi.URLParam(req, "id")
-	id, err := uuid.Parse(idStr)
+// boardFromKey returns the board a key grants access to and the access it
+// grants: the board's own key can edit it, a view key can only read it.
+func (r *Router) boardFromKey(ctx context.Context, key string) (*domain.Board, domain.Access, error) {
+	if board, err := r.boardRepo.GetByKey(ctx, key); err == nil {
+		return board, domain.AccessEdit, nil
+	}
+	board, err := r.boardRepo.GetByViewKey(ctx, key)
+	if err != nil {
+		return nil, "", err
+	}
+	return board, domain.AccessView, nil
+}
+
+// boardAccessFromPath resolves the board named by the {key} URL parameter and
+// the access the key grants, which it reports in the X-Kanbin-Access header.
+// It writes the error response and returns nil if the key is malformed,
+// unknown or the board has expired. Unlike activeBoardFromPath it lets view
+// keys through whatever the method.
+func (r *Router) boardAccessFromPath(w http.ResponseWriter, req *http.Request) (*domain.Board, domain.Access) {
+	key := chi.URLParam(req, "key")
+	if !boardKeyRe.MatchString(key) {
+		respondError(w, http.StatusBadRequest, "Invalid board key format")
+		return nil, ""
+	}
+
+	board, access, err := r.boardFromKey(req.Context(), key)
+	if err != nil {
+		respondError(w, http.StatusNotFound, "Board not found")
+		return nil, ""
+	}
+	w.Header().Set(accessHeader, string(access))
+
+	if time.Now().After(board.ExpiresAt) {
+		respondError(w, http.StatusGone, "Board has expired")
+		return nil, ""
+	}
+	return board, access
+}
+
+// activeBoardFromPath resolves the board named by the {key} URL parameter. It
+// writes the error response and returns nil if the key is malformed, unknown
+// or the board has expired, or if it is a view key and the request is not a
+// read.
+func (r *Router) activeBoardFromPath(w http.ResponseWriter, req *http.Request) *domain.Board {
+	board, access := r.boardAccessFromPath(w, req)
+	if board == nil || !allowAccess(w, req, access) {
+		return nil
+	}
+	return board
+}
+
+// taskFromPath resolves the task named by the {id} URL parameter and verifies
+// that it belongs to the board named by {key}. It writes the error response
+// and returns nils if either check fails or the board has expired.
+func (r *Router) taskFromPath(w http.ResponseWriter, req *http.Request) (*domain.Task, *domain.Board) {
+	id, err := uuid.Parse(chi.URLParam(req, "id"))
 	if err != nil {
 		respondError(w, http.StatusBadRequest, "Invalid task ID format")
-		return
+		return nil, nil
 	}
 
-	// Board-ownership verification: board key is in the URL path.
 	boardKey := chi.URLParam(req, "key")
 	if !boardKeyRe.MatchString(boardKey) {
 		respondError(w, http.StatusBadRequest, "Invalid board key format")
-		return
+		return nil, nil
 	}
 
 	task, err := r.taskRepo.GetByID(req.Context(), id)
 	if err != nil {
 		// Return 403 to avoid confirming whether the task exists.
 		respondError(w, http.StatusForbidden, "Forbidden")
-		return
+		return nil, nil
 	}
 
-	board, err := r.boardRepo.GetByID(req.Context(), task.BoardID)
-	if err != nil || board.Key != boardKey {
+	board, access, err := r.boardFromKey(req.Context(), boardKey)
+	if err != nil || board.ID != task.BoardID {
 		respondError(w, http.StatusForbidden, "Forbidden")
-		return
+		return nil, nil
 	}
+	w.Header().Set(accessHeader, string(access))
 
-	// Check expiry
 	if time.Now().After(board.ExpiresAt) {
 		respondError(w, http.StatusGone, "Board has expired")
-		return
+		return nil, nil
+	}
+	if !allowAccess(w, req, access) {
+		return nil, nil
 	}
+	return task, board
+}
 
-	if err := r.taskRepo.Delete(req.Context(), id); err != nil {
-		respondError(w, http.StatusInternalServerError, "Failed to delete task")
-		return
+// touchTask bumps the task's UpdatedAt and version so board and task ETags
+// change when its checklist or dependencies do. Touching changes nothing
+// else, so if the task was edited concurrently it is reloaded and touched
+// again rather than failing.
+func (r *Router) touchTask(req *http.Request, task *domain.Task) error {
+	for attempt := 1; ; attempt++ {
+		task.UpdatedAt = time.Now()
+		err := r.taskRepo.Update(req.Context(), task)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, domain.ErrVersionConflict) || attempt == maxTouchAttempts {
+			return err
+		}
+		fresh, err := r.taskRepo.GetByID(req.Context(), task.ID)
+		if err != nil {
+			return err
+		}
+		*task = *fresh
 	}
+	r.publish(task.BoardID, eventTaskUpdated, task)
+	return nil
+}
 
-	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+// respondTask writes the task with its version ETag.
+func respondTask(w http.ResponseWriter, status int, task *domain.Task) {
+	w.Header().Set("ETag", utils.VersionETag(task.Version))
+	respondJSON(w, status, task)
+}
+
+// checkIfMatch enforces an If-Match header against the task's version ETag.
+// It writes 412 Precondition Failed and returns false on a mismatch; without
+// the header every version matches.
+func checkIfMatch(w http.ResponseWriter, req *http.Request, task *domain.Task) bool {
+	ifMatch := req.Header.Get("If-Match")
+	if ifMatch == "" || utils.MatchETag(ifMatch, utils.VersionETag(task.Version)) {
+		return true
+	}
+	w.Header().Set("ETag", utils.VersionETag(task.Version))
+	respondError(w, http.StatusPreconditionFailed, taskChangedMessage)
+	return false
+}
+
+// versionConflict reports a task that changed between being read and being
+// written: 412 if the client asked for a version with If-Match, otherwise 409.
+func versionConflict(req *http.Request) (int, string) {
+	if req.Header.Get("If-Match") != "" {
+		return http.StatusPreconditionFailed, taskChangedMessage
+	}
+	return http.StatusConflict, taskChangedMessage
+}
+
+// touchBoard bumps the board's UpdatedAt so board ETags change when its lanes
+// or settings do.
+func (r *Router) touchBoard(req *http.Request, board *domain.Board) error {
+	board.UpdatedAt = time.Now()
+	if err := r.boardRepo.Update(req.Context(), board); err != nil {
+		return err
+	}
+	r.publish(board.ID, eventBoardUpdated, board)
+	return nil
+}
+
+// moveID returns a copy of ids with the element at index from moved to index
+// to, clamping to to the valid range.
+func moveID(ids []uuid.UUID, from, to int) []uuid.UUID {
+	if to < 0 {
+		to = 0
+	}
+	if to > len(ids)-1 {
+		to = len(ids) - 1
+	}
+	moved := ids[from]
+	out := make([]uuid.UUID, 0, len(ids))
+	out = append(out, ids[:from]...)
+	out = append(out, ids[from+1:]...)
+	out = append(out[:to], append([]uuid.UUID{moved}, out[to:]...)...)
+	return out
+}
+
+func indexOfID(ids []uuid.UUID, id uuid.UUID) int {
+	for i, v := range ids {
+		if v == id {
+			return i
+		}
+	}
+	return -1
 }
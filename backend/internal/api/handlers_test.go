@@ -1,11 +1,14 @@
 package api
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"slices"
+	"sort"
 	"strings"
 	"testing"
 	"time"
@@ -13,54 +16,256 @@ import (
 	"github.com/google/uuid"
 	"github.com/zeeshanejaz/kanbin/backend/internal/config"
 	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+	"github.com/zeeshanejaz/kanbin/backend/internal/rank"
 )
 
 // ─── Mock repositories ────────────────────────────────────────────────────────
 
 type mockBoardRepo struct {
-	boards map[string]*domain.Board
+	// boards is keyed by plaintext key. Like the real repository, lookups
+	// return copies without the key, since only its hash is stored.
+	boards   map[string]*domain.Board
+	lanes    map[uuid.UUID]*domain.Lane
+	viewKeys map[uuid.UUID]*domain.ViewKey
+	// viewKeyIDs finds view keys by key, which viewKeys does not keep.
+	viewKeyIDs map[string]uuid.UUID
+	// expiryLocked makes DeleteExpired act as if another server is reaping.
+	expiryLocked bool
 }
 
 func newMockBoardRepo() *mockBoardRepo {
-	return &mockBoardRepo{boards: make(map[string]*domain.Board)}
+	return &mockBoardRepo{
+		boards:     make(map[string]*domain.Board),
+		lanes:      make(map[uuid.UUID]*domain.Lane),
+		viewKeys:   make(map[uuid.UUID]*domain.ViewKey),
+		viewKeyIDs: make(map[string]uuid.UUID),
+	}
 }
 
 func (m *mockBoardRepo) Create(_ context.Context, b *domain.Board) error {
-	m.boards[b.Key] = b
+	stored := *b
+	m.boards[b.Key] = &stored
+	for _, l := range b.Lanes {
+		m.lanes[l.ID] = l
+	}
 	return nil
 }
 
+func withoutKey(b *domain.Board) *domain.Board {
+	loaded := *b
+	loaded.Key = ""
+	loaded.Lanes = nil
+	return &loaded
+}
+
 func (m *mockBoardRepo) GetByKey(_ context.Context, key string) (*domain.Board, error) {
 	b, ok := m.boards[key]
 	if !ok {
 		return nil, fmt.Errorf("not found")
 	}
-	return b, nil
+	return withoutKey(b), nil
 }
 
 func (m *mockBoardRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Board, error) {
 	for _, b := range m.boards {
 		if b.ID == id {
-			return b, nil
+			return withoutKey(b), nil
 		}
 	}
 	return nil, fmt.Errorf("not found")
 }
 
+func (m *mockBoardRepo) Update(_ context.Context, b *domain.Board) error {
+	for _, stored := range m.boards {
+		if stored.ID == b.ID {
+			key, lanes := stored.Key, stored.Lanes
+			*stored = *b
+			stored.Key, stored.Lanes = key, lanes
+		}
+	}
+	return nil
+}
+
+func (m *mockBoardRepo) SlideExpiry(_ context.Context, key string, now time.Time) error {
+	b, ok := m.boards[key]
+	if !ok || !b.SlidingExpiry || !b.ExpiresAt.After(now) {
+		return nil
+	}
+	if slid := now.Add(time.Duration(b.TTLSeconds) * time.Second); slid.After(b.ExpiresAt) {
+		b.ExpiresAt = slid
+		b.UpdatedAt = now
+	}
+	return nil
+}
+
 func (m *mockBoardRepo) DeleteByKey(_ context.Context, key string) error {
 	delete(m.boards, key)
 	return nil
 }
 
+func (m *mockBoardRepo) DeleteExpired(_ context.Context, before time.Time) ([]uuid.UUID, error) {
+	if m.expiryLocked {
+		return nil, domain.ErrExpiryInProgress
+	}
+	var ids []uuid.UUID
+	for key, b := range m.boards {
+		if b.ExpiresAt.Before(before) {
+			ids = append(ids, b.ID)
+			delete(m.boards, key)
+		}
+	}
+	return ids, nil
+}
+
+func (m *mockBoardRepo) GetByViewKey(ctx context.Context, key string) (*domain.Board, error) {
+	if vk, ok := m.viewKeys[m.viewKeyIDs[key]]; ok {
+		return m.GetByID(ctx, vk.BoardID)
+	}
+	return nil, fmt.Errorf("not found")
+}
+
+func (m *mockBoardRepo) CreateViewKey(_ context.Context, vk *domain.ViewKey) error {
+	stored := *vk
+	stored.Key = ""
+	m.viewKeys[vk.ID] = &stored
+	m.viewKeyIDs[vk.Key] = vk.ID
+	return nil
+}
+
+func (m *mockBoardRepo) ListViewKeys(_ context.Context, boardID uuid.UUID) ([]*domain.ViewKey, error) {
+	var viewKeys []*domain.ViewKey
+	for _, vk := range m.viewKeys {
+		if vk.BoardID == boardID {
+			viewKeys = append(viewKeys, vk)
+		}
+	}
+	sort.Slice(viewKeys, func(i, j int) bool { return viewKeys[i].CreatedAt.Before(viewKeys[j].CreatedAt) })
+	return viewKeys, nil
+}
+
+func (m *mockBoardRepo) DeleteViewKey(_ context.Context, id uuid.UUID) error {
+	delete(m.viewKeys, id)
+	return nil
+}
+
+func (m *mockBoardRepo) ListLanes(_ context.Context, boardID uuid.UUID) ([]*domain.Lane, error) {
+	var lanes []*domain.Lane
+	for _, l := range m.lanes {
+		if l.BoardID == boardID {
+			lanes = append(lanes, l)
+		}
+	}
+	sort.Slice(lanes, func(i, j int) bool { return lanes[i].Position < lanes[j].Position })
+	return lanes, nil
+}
+
+func (m *mockBoardRepo) CreateLane(_ context.Context, l *domain.Lane) error {
+	m.lanes[l.ID] = l
+	return nil
+}
+
+func (m *mockBoardRepo) UpdateLane(ctx context.Context, l *domain.Lane, _ domain.TaskStatus, laneIDs []uuid.UUID) error {
+	if stored, ok := m.lanes[l.ID]; ok {
+		stored.Name = l.Name
+		stored.WIPLimit = l.WIPLimit
+	}
+	if laneIDs != nil {
+		return m.ReorderLanes(ctx, l.BoardID, laneIDs)
+	}
+	return nil
+}
+
+func (m *mockBoardRepo) ReorderLanes(_ context.Context, _ uuid.UUID, laneIDs []uuid.UUID) error {
+	for i, id := range laneIDs {
+		if l, ok := m.lanes[id]; ok {
+			l.Position = i
+		}
+	}
+	return nil
+}
+
+func (m *mockBoardRepo) DeleteLane(_ context.Context, id uuid.UUID) error {
+	delete(m.lanes, id)
+	return nil
+}
+
 type mockTaskRepo struct {
 	tasks map[uuid.UUID]*domain.Task
+	// lanes is shared with mockBoardRepo so WIP limits can be enforced.
+	lanes map[uuid.UUID]*domain.Lane
 }
 
 func newMockTaskRepo() *mockTaskRepo {
 	return &mockTaskRepo{tasks: make(map[uuid.UUID]*domain.Task)}
 }
 
+// checkWIPLimit mirrors the postgres repository's lane limit check.
+func (m *mockTaskRepo) checkWIPLimit(t *domain.Task) error {
+	if t.ArchivedAt != nil {
+		return nil
+	}
+	for _, l := range m.lanes {
+		if l.BoardID != t.BoardID || l.Name != t.Status || l.WIPLimit == 0 {
+			continue
+		}
+		count := 0
+		for _, other := range m.tasks {
+			if other.ID != t.ID && other.BoardID == t.BoardID && other.ArchivedAt == nil && other.Status == t.Status {
+				count++
+			}
+		}
+		if count >= l.WIPLimit {
+			return domain.ErrWIPLimitReached
+		}
+	}
+	return nil
+}
+
+// laneOrder mirrors the postgres repository: the unarchived tasks of a lane
+// in rank order, leaving out exclude.
+func (m *mockTaskRepo) laneOrder(boardID uuid.UUID, status domain.TaskStatus, exclude uuid.UUID) []*domain.Task {
+	var lane []*domain.Task
+	for _, t := range m.tasks {
+		if t.BoardID == boardID && t.Status == status && t.ArchivedAt == nil && t.ID != exclude {
+			lane = append(lane, t)
+		}
+	}
+	sort.Slice(lane, func(i, j int) bool {
+		if lane[i].Rank != lane[j].Rank {
+			return lane[i].Rank < lane[j].Rank
+		}
+		return lane[i].ID.String() < lane[j].ID.String()
+	})
+	return lane
+}
+
+// rankAt returns a rank placing a task at index i of lane.
+func rankAt(lane []*domain.Task, i int) string {
+	var before, after string
+	if i > 0 {
+		before = lane[i-1].Rank
+	}
+	if i < len(lane) {
+		after = lane[i].Rank
+	}
+	return rank.Between(before, after)
+}
+
+// derivePositions sets Position from rank order, as postgres does on read.
+func (m *mockTaskRepo) derivePositions(boardID uuid.UUID, status domain.TaskStatus) {
+	for i, t := range m.laneOrder(boardID, status, uuid.Nil) {
+		t.Position = i
+	}
+}
+
 func (m *mockTaskRepo) Create(_ context.Context, t *domain.Task) error {
+	if err := m.checkWIPLimit(t); err != nil {
+		return err
+	}
+	lane := m.laneOrder(t.BoardID, t.Status, t.ID)
+	t.Position = len(lane)
+	t.Rank = rankAt(lane, t.Position)
+	t.Version = 1
 	m.tasks[t.ID] = t
 	return nil
 }
@@ -70,49 +275,492 @@ func (m *mockTaskRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Task, e
 	if !ok {
 		return nil, fmt.Errorf("not found")
 	}
-	return t, nil
+	// Return a copy, as a database read would, so version checks are real.
+	copied := *t
+	return &copied, nil
 }
 
-func (m *mockTaskRepo) GetByBoardID(_ context.Context, boardID uuid.UUID) ([]*domain.Task, error) {
+func (m *mockTaskRepo) GetByBoardID(_ context.Context, boardID uuid.UUID, filter domain.TaskFilter) ([]*domain.Task, error) {
+	var lanes []*domain.Lane
+	for _, l := range m.lanes {
+		if l.BoardID == boardID {
+			lanes = append(lanes, l)
+		}
+	}
 	var tasks []*domain.Task
 	for _, t := range m.tasks {
-		if t.BoardID == boardID {
-			tasks = append(tasks, t)
+		if t.BoardID != boardID || !hasAllLabels(t, filter.Labels) {
+			continue
 		}
+		if t.ArchivedAt != nil && !filter.IncludeArchived {
+			continue
+		}
+		if filter.Assignee != nil && t.Assignee != *filter.Assignee {
+			continue
+		}
+		// Test boards use the default lanes, so DONE is the last lane.
+		if filter.Overdue && (t.DueAt == nil || !t.DueAt.Before(time.Now()) || t.Status == domain.StatusDone) {
+			continue
+		}
+		if len(filter.Statuses) > 0 && !slices.Contains(filter.Statuses, t.Status) {
+			continue
+		}
+		if filter.After != nil && compareCursors(domain.NewTaskCursor(t, lanes), *filter.After, filter.Sort) <= 0 {
+			continue
+		}
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return compareCursors(domain.NewTaskCursor(tasks[i], lanes), domain.NewTaskCursor(tasks[j], lanes), filter.Sort) < 0
+	})
+	if filter.Limit > 0 && len(tasks) > filter.Limit {
+		tasks = tasks[:filter.Limit]
 	}
 	return tasks, nil
 }
 
+// compareCursors orders tasks as the postgres repository does.
+func compareCursors(a, b domain.TaskCursor, sortBy domain.TaskSort) int {
+	if sortBy == domain.SortByPriority {
+		if c := cmp.Compare(b.PriorityRank, a.PriorityRank); c != 0 {
+			return c
+		}
+		switch {
+		case a.DueAt == nil && b.DueAt != nil:
+			return 1
+		case a.DueAt != nil && b.DueAt == nil:
+			return -1
+		case a.DueAt != nil && !a.DueAt.Equal(*b.DueAt):
+			return a.DueAt.Compare(*b.DueAt)
+		}
+	}
+	return cmp.Or(
+		cmp.Compare(a.LanePosition, b.LanePosition),
+		cmp.Compare(a.Status, b.Status),
+		cmp.Compare(a.Rank, b.Rank),
+		cmp.Compare(a.ID.String(), b.ID.String()),
+	)
+}
+
+// Search matches tasks whose title or description contains every word of the
+// query, case-insensitively, ranking title matches first.
+func (m *mockTaskRepo) Search(_ context.Context, boardID uuid.UUID, terms string, limit int) ([]*domain.TaskSearchResult, error) {
+	var results []*domain.TaskSearchResult
+	for _, t := range m.tasks {
+		if t.BoardID != boardID || t.ArchivedAt != nil {
+			continue
+		}
+		title, desc := strings.ToLower(t.Title), strings.ToLower(t.Description)
+		score, matched := 0.0, true
+		for _, word := range strings.Fields(strings.ToLower(terms)) {
+			switch {
+			case strings.Contains(title, word):
+				score += 1
+			case strings.Contains(desc, word):
+				score += 0.4
+			default:
+				matched = false
+			}
+		}
+		if matched {
+			results = append(results, &domain.TaskSearchResult{Task: t, Score: score, Snippet: t.Title})
+		}
+	}
+	sort.Slice(results, func(i, j int) bool { return results[i].Score > results[j].Score })
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+func (m *mockTaskRepo) ListAssignees(_ context.Context, boardID uuid.UUID) ([]string, error) {
+	seen := make(map[string]bool)
+	var assignees []string
+	for _, t := range m.tasks {
+		if t.BoardID == boardID && t.ArchivedAt == nil && t.Assignee != "" && !seen[t.Assignee] {
+			seen[t.Assignee] = true
+			assignees = append(assignees, t.Assignee)
+		}
+	}
+	sort.Strings(assignees)
+	return assignees, nil
+}
+
+// hasAllLabels mirrors the repository's filter, which compares the number of
+// distinct matching labels with the number of labels asked for.
+func hasAllLabels(t *domain.Task, labels []string) bool {
+	matched := make(map[string]bool)
+	for _, want := range labels {
+		if slices.Contains(t.Labels, want) {
+			matched[want] = true
+		}
+	}
+	return len(matched) == len(labels)
+}
+
 func (m *mockTaskRepo) Update(_ context.Context, t *domain.Task) error {
+	stored, ok := m.tasks[t.ID]
+	if !ok {
+		return fmt.Errorf("not found")
+	}
+	if stored.Version != t.Version {
+		return domain.ErrVersionConflict
+	}
+	if err := m.checkWIPLimit(t); err != nil {
+		return err
+	}
+	t.Rank = stored.Rank
+	if t.ArchivedAt == nil && (stored.Status != t.Status || stored.ArchivedAt != nil || stored.Position != t.Position) {
+		lane := m.laneOrder(t.BoardID, t.Status, t.ID)
+		t.Position = min(max(t.Position, 0), len(lane))
+		t.Rank = rankAt(lane, t.Position)
+	}
+	t.Version++
 	m.tasks[t.ID] = t
+	m.derivePositions(t.BoardID, t.Status)
+	m.derivePositions(t.BoardID, stored.Status)
 	return nil
 }
 
+func (m *mockTaskRepo) Move(ctx context.Context, t *domain.Task, neighbour domain.Neighbour) error {
+	index := slices.IndexFunc(m.laneOrder(t.BoardID, t.Status, t.ID), func(other *domain.Task) bool {
+		return other.ID == neighbour.ID
+	})
+	if index < 0 {
+		return domain.ErrNeighbourNotInLane
+	}
+	if neighbour.After {
+		index++
+	}
+	t.Position = index
+	return m.Update(ctx, t)
+}
+
+func (m *mockTaskRepo) ApplyBatch(ctx context.Context, writes []domain.TaskWrite) error {
+	// Copy every task: writes renumber their neighbours in place.
+	snapshot := make(map[uuid.UUID]*domain.Task, len(m.tasks))
+	for id, t := range m.tasks {
+		copied := *t
+		snapshot[id] = &copied
+	}
+	versions := make(map[uuid.UUID]int)
+	for i, w := range writes {
+		write := m.Update
+		if w.Create {
+			write = m.Create
+		} else if v, ok := versions[w.Task.ID]; ok {
+			w.Task.Version = v
+		}
+		if w.Neighbour != nil {
+			neighbour := *w.Neighbour
+			write = func(ctx context.Context, t *domain.Task) error {
+				return m.Move(ctx, t, neighbour)
+			}
+		}
+		if err := write(ctx, w.Task); err != nil {
+			m.tasks = snapshot
+			return &domain.BatchError{Index: i, Err: err}
+		}
+		versions[w.Task.ID] = w.Task.Version
+	}
+	return nil
+}
+
+func (m *mockTaskRepo) RebalanceRanks(_ context.Context, maxLength int) ([]uuid.UUID, error) {
+	var boards []uuid.UUID
+	for _, t := range m.tasks {
+		if t.ArchivedAt != nil || len(t.Rank) <= maxLength {
+			continue
+		}
+		lane := m.laneOrder(t.BoardID, t.Status, uuid.Nil)
+		for i, r := range rank.Spread(len(lane)) {
+			lane[i].Rank = r
+			lane[i].UpdatedAt = time.Now()
+			lane[i].Version++
+		}
+		if !slices.Contains(boards, t.BoardID) {
+			boards = append(boards, t.BoardID)
+		}
+	}
+	return boards, nil
+}
+
 func (m *mockTaskRepo) Delete(_ context.Context, id uuid.UUID) error {
+	t, ok := m.tasks[id]
 	delete(m.tasks, id)
+	if ok {
+		m.derivePositions(t.BoardID, t.Status)
+	}
 	return nil
 }
 
 func (m *mockTaskRepo) CountByBoardID(_ context.Context, boardID uuid.UUID) (int, error) {
 	count := 0
 	for _, t := range m.tasks {
-		if t.BoardID == boardID {
+		if t.BoardID == boardID && t.ArchivedAt == nil {
 			count++
 		}
 	}
 	return count, nil
 }
 
+func (m *mockTaskRepo) CountByStatus(_ context.Context, boardID uuid.UUID, status domain.TaskStatus) (int, error) {
+	count := 0
+	for _, t := range m.tasks {
+		if t.BoardID == boardID && t.Status == status {
+			count++
+		}
+	}
+	return count, nil
+}
+
+type mockChecklistRepo struct {
+	items map[uuid.UUID]*domain.ChecklistItem
+	tasks *mockTaskRepo
+}
+
+func newMockChecklistRepo(tasks *mockTaskRepo) *mockChecklistRepo {
+	return &mockChecklistRepo{items: make(map[uuid.UUID]*domain.ChecklistItem), tasks: tasks}
+}
+
+func (m *mockChecklistRepo) Create(_ context.Context, item *domain.ChecklistItem) error {
+	m.items[item.ID] = item
+	return nil
+}
+
+func (m *mockChecklistRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.ChecklistItem, error) {
+	item, ok := m.items[id]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return item, nil
+}
+
+func (m *mockChecklistRepo) ListByTaskID(_ context.Context, taskID uuid.UUID) ([]*domain.ChecklistItem, error) {
+	var items []*domain.ChecklistItem
+	for _, item := range m.items {
+		if item.TaskID == taskID {
+			items = append(items, item)
+		}
+	}
+	sort.Slice(items, func(i, j int) bool { return items[i].Position < items[j].Position })
+	return items, nil
+}
+
+func (m *mockChecklistRepo) Update(_ context.Context, item *domain.ChecklistItem) error {
+	m.items[item.ID] = item
+	return nil
+}
+
+func (m *mockChecklistRepo) Reorder(_ context.Context, _ uuid.UUID, itemIDs []uuid.UUID) error {
+	for i, id := range itemIDs {
+		if item, ok := m.items[id]; ok {
+			item.Position = i
+		}
+	}
+	return nil
+}
+
+func (m *mockChecklistRepo) Delete(_ context.Context, id uuid.UUID) error {
+	delete(m.items, id)
+	return nil
+}
+
+func (m *mockChecklistRepo) SummarizeByBoardID(_ context.Context, boardID uuid.UUID) (map[uuid.UUID]domain.ChecklistSummary, error) {
+	summaries := make(map[uuid.UUID]domain.ChecklistSummary)
+	for _, item := range m.items {
+		task, ok := m.tasks.tasks[item.TaskID]
+		if !ok || task.BoardID != boardID {
+			continue
+		}
+		s := summaries[item.TaskID]
+		s.Total++
+		if item.Done {
+			s.Done++
+		}
+		summaries[item.TaskID] = s
+	}
+	return summaries, nil
+}
+
+type mockDependencyRepo struct {
+	deps  []*domain.Dependency
+	tasks *mockTaskRepo
+}
+
+func newMockDependencyRepo(tasks *mockTaskRepo) *mockDependencyRepo {
+	return &mockDependencyRepo{tasks: tasks}
+}
+
+func (m *mockDependencyRepo) Create(ctx context.Context, boardID uuid.UUID, dep *domain.Dependency) error {
+	deps, _ := m.ListByBoardID(ctx, boardID)
+	if domain.DependsOn(deps, dep.BlockedByID, dep.TaskID) {
+		return domain.ErrDependencyCycle
+	}
+	m.deps = append(m.deps, dep)
+	return nil
+}
+
+func (m *mockDependencyRepo) Delete(_ context.Context, taskID, blockedByID uuid.UUID) error {
+	kept := m.deps[:0]
+	for _, d := range m.deps {
+		if d.TaskID != taskID || d.BlockedByID != blockedByID {
+			kept = append(kept, d)
+		}
+	}
+	m.deps = kept
+	return nil
+}
+
+func (m *mockDependencyRepo) ListByBoardID(_ context.Context, boardID uuid.UUID) ([]*domain.Dependency, error) {
+	var deps []*domain.Dependency
+	for _, d := range m.deps {
+		if t, ok := m.tasks.tasks[d.TaskID]; ok && t.BoardID == boardID {
+			deps = append(deps, d)
+		}
+	}
+	return deps, nil
+}
+
+type mockCommentRepo struct {
+	comments map[uuid.UUID]*domain.Comment
+}
+
+func newMockCommentRepo() *mockCommentRepo {
+	return &mockCommentRepo{comments: make(map[uuid.UUID]*domain.Comment)}
+}
+
+func (m *mockCommentRepo) Create(_ context.Context, c *domain.Comment) error {
+	m.comments[c.ID] = c
+	return nil
+}
+
+func (m *mockCommentRepo) GetByID(_ context.Context, id uuid.UUID) (*domain.Comment, error) {
+	c, ok := m.comments[id]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return c, nil
+}
+
+func (m *mockCommentRepo) ListByTaskID(_ context.Context, taskID uuid.UUID) ([]*domain.Comment, error) {
+	var comments []*domain.Comment
+	for _, c := range m.comments {
+		if c.TaskID == taskID {
+			comments = append(comments, c)
+		}
+	}
+	sort.Slice(comments, func(i, j int) bool { return comments[i].CreatedAt.Before(comments[j].CreatedAt) })
+	return comments, nil
+}
+
+func (m *mockCommentRepo) CountByTaskID(ctx context.Context, taskID uuid.UUID) (int, error) {
+	comments, _ := m.ListByTaskID(ctx, taskID)
+	return len(comments), nil
+}
+
+func (m *mockCommentRepo) Delete(_ context.Context, id uuid.UUID) error {
+	delete(m.comments, id)
+	return nil
+}
+
+type mockActivityRepo struct {
+	entries []*domain.Activity
+}
+
+func (m *mockActivityRepo) Create(_ context.Context, a *domain.Activity) error {
+	// board_activity.changes is NOT NULL, and pgx stores a nil map as NULL.
+	if a.Changes == nil {
+		return fmt.Errorf("activity %s has nil changes", a.Action)
+	}
+	a.ID = int64(len(m.entries) + 1)
+	m.entries = append(m.entries, a)
+	return nil
+}
+
+func (m *mockActivityRepo) ListByBoardID(_ context.Context, boardID uuid.UUID, beforeID int64, limit int) ([]*domain.Activity, error) {
+	var entries []*domain.Activity
+	for i := len(m.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		a := m.entries[i]
+		if a.BoardID == boardID && (beforeID == 0 || a.ID < beforeID) {
+			entries = append(entries, a)
+		}
+	}
+	return entries, nil
+}
+
+type mockIdempotencyRepo struct {
+	records map[string]*domain.IdempotentResponse
+	created map[string]time.Time
+	tokens  map[string]uuid.UUID
+}
+
+func newMockIdempotencyRepo() *mockIdempotencyRepo {
+	return &mockIdempotencyRepo{
+		records: make(map[string]*domain.IdempotentResponse),
+		created: make(map[string]time.Time),
+		tokens:  make(map[string]uuid.UUID),
+	}
+}
+
+func (m *mockIdempotencyRepo) Claim(_ context.Context, key, fingerprint string, notBefore, leaseBefore time.Time) (uuid.UUID, *domain.IdempotentResponse, error) {
+	stored, ok := m.records[key]
+	abandoned := ok && stored.Status == 0 && m.created[key].Before(leaseBefore)
+	if ok && m.created[key].After(notBefore) && !abandoned {
+		copied := *stored
+		return uuid.Nil, &copied, nil
+	}
+	m.records[key] = &domain.IdempotentResponse{Fingerprint: fingerprint}
+	m.created[key] = time.Now()
+	m.tokens[key] = uuid.New()
+	return m.tokens[key], nil, nil
+}
+
+func (m *mockIdempotencyRepo) Complete(_ context.Context, key string, token uuid.UUID, resp *domain.IdempotentResponse) error {
+	if m.tokens[key] == token {
+		m.records[key] = resp
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepo) Release(_ context.Context, key string, token uuid.UUID) error {
+	if m.tokens[key] == token {
+		delete(m.records, key)
+		delete(m.created, key)
+		delete(m.tokens, key)
+	}
+	return nil
+}
+
+func (m *mockIdempotencyRepo) DeleteBefore(_ context.Context, t time.Time) (int64, error) {
+	var n int64
+	for key, created := range m.created {
+		if created.Before(t) {
+			m.Release(context.Background(), key, m.tokens[key])
+			n++
+		}
+	}
+	return n, nil
+}
+
 // ─── Helpers ─────────────────────────────────────────────────────────────────
 
 func newTestRouter() (*Router, *mockBoardRepo, *mockTaskRepo) {
 	br := newMockBoardRepo()
 	tr := newMockTaskRepo()
+	tr.lanes = br.lanes
 	cfg := &config.Config{
 		Port:           "8080",
 		AllowedOrigins: []string{"http://localhost:5173"},
+		MaxBoardTTL:    30 * 24 * time.Hour,
 	}
-	return NewRouter(br, tr, cfg), br, tr
+	r := NewRouter(br, tr, newMockChecklistRepo(tr), newMockDependencyRepo(tr), newMockCommentRepo(), &mockActivityRepo{}, newMockIdempotencyRepo(), cfg)
+
+	// Every test request comes from one address; give each test fresh limits.
+	visitorsMu.Lock()
+	visitors = make(map[string]*visitor)
+	visitorsMu.Unlock()
+	return r, br, tr
 }
 
 func seedBoard(br *mockBoardRepo, key string, expired bool) *domain.Board {
@@ -125,9 +773,16 @@ func seedBoard(br *mockBoardRepo, key string, expired bool) *domain.Board {
 		Key:       key,
 		Title:     "Test Board",
 		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
 		ExpiresAt: expiresAt,
+
+		TTLSeconds: int((7 * 24 * time.Hour).Seconds()),
 	}
 	br.boards[key] = b
+	for i, name := range domain.DefaultLanes {
+		l := &domain.Lane{ID: uuid.New(), BoardID: b.ID, Name: name, Position: i}
+		br.lanes[l.ID] = l
+	}
 	return b
 }
 
@@ -137,10 +792,13 @@ func seedTask(tr *mockTaskRepo, boardID uuid.UUID) *domain.Task {
 		BoardID:   boardID,
 		Title:     "Test Task",
 		Status:    domain.StatusTodo,
-		Position:  0,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
+		Version:   1,
 	}
+	lane := tr.laneOrder(boardID, t.Status, t.ID)
+	t.Position = len(lane)
+	t.Rank = rankAt(lane, t.Position)
 	tr.tasks[t.ID] = t
 	return t
 }
@@ -246,6 +904,25 @@ func TestCreateBoard_ValidTitle_Returns201(t *testing.T) {
 	}
 }
 
+func TestBoardKey_OnlyInCreateResponse(t *testing.T) {
+	r, _, _ := newTestRouter()
+	rr, board := createBoard(t, r, `{"title":"My Board"}`)
+	if rr.Code != http.StatusCreated || !boardKeyRe.MatchString(board.Key) {
+		t.Fatalf("expected 201 with the key, got %d: %q", rr.Code, board.Key)
+	}
+
+	for _, c := range []struct{ method, path, body string }{
+		{http.MethodGet, board.Key, ""},
+		{http.MethodPut, board.Key, `{"title":"Renamed"}`},
+		{http.MethodPost, board.Key + "/extend", ""},
+	} {
+		rr := doRequest(r, c.method, c.path, c.body)
+		if rr.Code != http.StatusOK || strings.Contains(rr.Body.String(), board.Key) {
+			t.Errorf("%s %s: expected 200 without the key, got %d: %s", c.method, c.path, rr.Code, rr.Body.String())
+		}
+	}
+}
+
 // ─── Input validation: createTask ────────────────────────────────────────────
 
 func TestCreateTask_EmptyTitle_Returns400(t *testing.T) {
@@ -465,3 +1142,445 @@ func TestCreateTask_HidesBoardID(t *testing.T) {
 		t.Error("task 'board_id' field must not be present in JSON response")
 	}
 }
+
+// ─── Labels ──────────────────────────────────────────────────────────────────
+
+func TestCreateTask_Labels_NormalizedAndDeduplicated(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	body := `{"title":"ok","labels":["infra"," bug","infra"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var task domain.Task
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if strings.Join(task.Labels, ",") != "bug,infra" {
+		t.Errorf("expected labels [bug infra], got %v", task.Labels)
+	}
+}
+
+func TestCreateTask_EmptyLabel_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ok","labels":["  "]}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestUpdateTask_ClearLabels(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	task.Labels = []string{"bug"}
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), strings.NewReader(`{"labels":[]}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(tr.tasks[task.ID].Labels) != 0 {
+		t.Errorf("expected labels to be cleared, got %v", tr.tasks[task.ID].Labels)
+	}
+}
+
+func TestGetBoard_FilterByLabel(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	both := seedTask(tr, board.ID)
+	both.Labels = []string{"bug", "infra"}
+	bugOnly := seedTask(tr, board.ID)
+	bugOnly.Labels = []string{"bug"}
+	seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"?label=bug&label=infra", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var resp BoardResponse
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Tasks) != 1 || resp.Tasks[0].ID != both.ID {
+		t.Errorf("expected only the task labelled bug+infra, got %d tasks", len(resp.Tasks))
+	}
+
+	// Filter values are trimmed and de-duplicated like stored labels.
+	req = httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"?label=bug&label=+bug+&label=", nil)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	resp = BoardResponse{}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(resp.Tasks) != 2 {
+		t.Errorf("expected both tasks labelled bug, got %d tasks", len(resp.Tasks))
+	}
+}
+
+// ─── Assignees ───────────────────────────────────────────────────────────────
+
+func TestUpdateTask_AssigneeTooLong_Returns400(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	body := `{"assignee":"` + strings.Repeat("x", 101) + `"}`
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestGetBoard_FilterByAssignee(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	mine := seedTask(tr, board.ID)
+	mine.Assignee = "agent-1"
+	theirs := seedTask(tr, board.ID)
+	theirs.Assignee = "agent-2"
+	unassigned := seedTask(tr, board.ID)
+
+	cases := []struct {
+		query string
+		want  uuid.UUID
+	}{
+		{"?assignee=agent-1", mine.ID},
+		{"?assignee=", unassigned.ID},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+c.query, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", c.query, rr.Code)
+		}
+		var resp BoardResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		if len(resp.Tasks) != 1 || resp.Tasks[0].ID != c.want {
+			t.Errorf("%s: expected exactly one matching task, got %d", c.query, len(resp.Tasks))
+		}
+		if strings.Join(resp.Assignees, ",") != "agent-1,agent-2" {
+			t.Errorf("%s: expected board assignees [agent-1 agent-2], got %v", c.query, resp.Assignees)
+		}
+	}
+}
+
+// ─── Board updates ───────────────────────────────────────────────────────────
+
+func TestUpdateBoard_ChangesETag(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+
+	get := func() string {
+		req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr.Header().Get("ETag")
+	}
+	before := get()
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey, strings.NewReader(`{"title":"Renamed","enforce_dependencies":true}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if b := br.boards[testKey]; b.Title != "Renamed" || !b.EnforceDependencies {
+		t.Errorf("board not updated: %+v", b)
+	}
+	if get() == before {
+		t.Error("expected ETag to change after board update")
+	}
+}
+
+// ─── Due dates and priorities ────────────────────────────────────────────────
+
+func TestCreateTask_InvalidPriority_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ok","priority":"SOMEDAY"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestCreateTask_InvalidDueDate_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ok","due_at":"next tuesday"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestCreateTask_DateOnlyDueDate_EndOfDay(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ok","due_at":"2026-03-01","priority":"HIGH"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var task domain.Task
+	if err := json.NewDecoder(rr.Body).Decode(&task); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	want := time.Date(2026, 3, 1, 23, 59, 59, 0, time.UTC)
+	if task.DueAt == nil || !task.DueAt.Equal(want) {
+		t.Errorf("expected due %v, got %v", want, task.DueAt)
+	}
+}
+
+func TestUpdateTask_ClearDueDate(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	due := time.Now()
+	task.DueAt = &due
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), strings.NewReader(`{"due_at":""}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if tr.tasks[task.ID].DueAt != nil {
+		t.Error("expected due date to be cleared")
+	}
+}
+
+func TestGetBoard_OverdueAndPrioritySort(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	overdue := seedTask(tr, board.ID)
+	overdue.DueAt = &past
+	overdue.Priority = domain.PriorityLow
+	doneLate := seedTask(tr, board.ID)
+	doneLate.DueAt = &past
+	doneLate.Status = domain.StatusDone
+	urgent := seedTask(tr, board.ID)
+	urgent.DueAt = &future
+	urgent.Priority = domain.PriorityUrgent
+
+	decode := func(query string) []*domain.Task {
+		req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+query, nil)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		if rr.Code != http.StatusOK {
+			t.Fatalf("%s: expected 200, got %d", query, rr.Code)
+		}
+		var resp BoardResponse
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+		return resp.Tasks
+	}
+
+	if tasks := decode("?overdue=true"); len(tasks) != 1 || tasks[0].ID != overdue.ID {
+		t.Errorf("expected only the open overdue task, got %d tasks", len(tasks))
+	}
+	if tasks := decode("?sort=priority"); len(tasks) != 3 || tasks[0].ID != urgent.ID {
+		t.Errorf("expected the urgent task first")
+	}
+}
+
+func TestGetBoard_InvalidSort_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"?sort=title", nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+// ─── Single task ─────────────────────────────────────────────────────────────
+
+func TestGetTask_ReturnsTaskWithDetails(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	task.Description = strings.Repeat("long description ", 100)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if rr.Header().Get("ETag") == "" {
+		t.Error("expected an ETag header")
+	}
+	var got domain.Task
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.ID != task.ID || got.Description != task.Description {
+		t.Error("expected the full task in the response")
+	}
+}
+
+func TestGetTask_WrongBoardKey_Returns403(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	seedBoard(br, "bbbbbbbbbbbbbbbb", false)
+	task := seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/bbbbbbbbbbbbbbbb/tasks/"+task.ID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Errorf("expected 403, got %d", rr.Code)
+	}
+}
+
+func TestGetTask_ETag(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	dependent := seedTask(tr, board.ID)
+	path := "/api/boards/" + testKey + "/tasks/" + task.ID.String()
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+	etag := rr.Header().Get("ETag")
+
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotModified {
+		t.Fatalf("expected 304, got %d", rr.Code)
+	}
+
+	// Another task depending on this one changes its blocks list.
+	time.Sleep(time.Millisecond)
+	body := `{"blocked_by":"` + task.ID.String() + `"}`
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost,
+		"/api/boards/"+testKey+"/tasks/"+dependent.ID.String()+"/dependencies", strings.NewReader(body)))
+
+	req = httptest.NewRequest(http.MethodGet, path, nil)
+	req.Header.Set("If-None-Match", etag)
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 after a new dependency, got %d", rr.Code)
+	}
+}
+
+// ─── Optimistic concurrency ──────────────────────────────────────────────────
+
+func TestUpdateTask_IfMatch(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	put := func(ifMatch, title string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPut, taskPath(task), strings.NewReader(`{"title":"`+title+`"}`))
+		req.Header.Set("If-Match", ifMatch)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := put(`"v1"`, "first")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if etag := rr.Header().Get("ETag"); etag != `"v2"` {
+		t.Errorf("expected ETag \"v2\", got %s", etag)
+	}
+	var got domain.Task
+	json.NewDecoder(rr.Body).Decode(&got)
+	if got.Version != 2 {
+		t.Errorf("expected version 2 in the body, got %d", got.Version)
+	}
+
+	// A second writer still holding version 1 must not overwrite the change.
+	rr = put(`"v1"`, "second")
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412, got %d", rr.Code)
+	}
+	if etag := rr.Header().Get("ETag"); etag != `"v2"` {
+		t.Errorf("expected the current ETag on 412, got %s", etag)
+	}
+	if title := tr.tasks[task.ID].Title; title != "first" {
+		t.Errorf("expected the first write to survive, got %q", title)
+	}
+
+	if rr := put("*", "third"); rr.Code != http.StatusOK {
+		t.Errorf("expected * to match any version, got %d", rr.Code)
+	}
+}
+
+func TestDeleteTask_IfMatchMismatch_Returns412(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodDelete, taskPath(task), nil)
+	req.Header.Set("If-Match", `"v7"`)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Fatalf("expected 412, got %d", rr.Code)
+	}
+	if tr.tasks[task.ID].ArchivedAt != nil {
+		t.Error("expected the task not to be archived")
+	}
+}
+
+func TestBatch_RepeatedUpdatesToOneTask_ChainVersions(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	body := `{"operations":[
+		{"op":"update","id":"` + task.ID.String() + `","task":{"title":"one"}},
+		{"op":"move","id":"` + task.ID.String() + `","task":{"status":"DONE"}}
+	]}`
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/batch", strings.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	stored := tr.tasks[task.ID]
+	if stored.Version != 3 || stored.Title != "one" || stored.Status != domain.StatusDone {
+		t.Errorf("expected both writes at version 3, got version %d %q %s", stored.Version, stored.Title, stored.Status)
+	}
+}
+
+func TestCreateTask_ReturnsVersionETag(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"new"}`)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rr.Code)
+	}
+	if etag := rr.Header().Get("ETag"); etag != `"v1"` {
+		t.Errorf("expected ETag \"v1\", got %s", etag)
+	}
+}
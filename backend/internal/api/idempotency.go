@@ -0,0 +1,223 @@
+package api
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	idempotencyHeader = "Idempotency-Key"
+	// idempotencyWindow is how long a stored response is replayed.
+	idempotencyWindow = 24 * time.Hour
+	// idempotencyLease is how long a request may hold a key before its claim
+	// is taken to be abandoned, for example by a server that died mid-request.
+	idempotencyLease = time.Minute
+	// maxIdempotencyKeyLength bounds the header; a UUID is 36 characters.
+	maxIdempotencyKeyLength = 255
+)
+
+// replayedHeaders are the response headers stored alongside the body.
+var replayedHeaders = []string{"Content-Type", "ETag"}
+
+// responseRecorder passes a response through while keeping a copy of it.
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (rec *responseRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *responseRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	rec.body.Write(b)
+	return rec.ResponseWriter.Write(b)
+}
+
+// requestFingerprint hashes a request body, canonicalising JSON so that
+// retries differing only in whitespace or key order still match.
+func requestFingerprint(body []byte) string {
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err == nil {
+		body, _ = json.Marshal(v)
+	}
+	sum := sha256.Sum256(body)
+	return hex.EncodeToString(sum[:])
+}
+
+// responseCipher returns the cipher that stored response bodies are sealed
+// with. Its key is derived from the Idempotency-Key, which only the client
+// holds, so stored bodies such as a new board's key are unreadable from the
+// database alone.
+func responseCipher(method, path, key string) (cipher.AEAD, error) {
+	sum := sha256.Sum256([]byte("response\n" + method + " " + path + "\n" + key))
+	block, err := aes.NewCipher(sum[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func sealBody(aead cipher.AEAD, body []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, body, nil), nil
+}
+
+func openBody(aead cipher.AEAD, sealed []byte) ([]byte, error) {
+	if len(sealed) < aead.NonceSize() {
+		return nil, errors.New("sealed body too short")
+	}
+	nonce, ciphertext := sealed[:aead.NonceSize()], sealed[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
+
+// idempotent makes a creation handler safe to retry. The first response to a
+// request carrying an Idempotency-Key is stored and replayed to later
+// requests with the same key and path for idempotencyWindow. Reusing a key
+// for a different body is rejected, and server errors, including panics, are
+// not stored so the request can be retried.
+func (r *Router) idempotent(next http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		key := req.Header.Get(idempotencyHeader)
+		if key == "" {
+			next(w, req)
+			return
+		}
+		if len(key) > maxIdempotencyKeyLength {
+			respondError(w, http.StatusBadRequest, "Idempotency-Key must be 255 characters or fewer")
+			return
+		}
+
+		body, err := io.ReadAll(req.Body)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+
+		// Keys are scoped to the path, so the same key may be used on
+		// different boards. Only a hash is stored.
+		sum := sha256.Sum256([]byte(req.Method + " " + req.URL.Path + "\n" + key))
+		keyHash := hex.EncodeToString(sum[:])
+		fingerprint := requestFingerprint(body)
+		aead, err := responseCipher(req.Method, req.URL.Path, key)
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check Idempotency-Key")
+			return
+		}
+
+		now := time.Now()
+		token, stored, err := r.idempotencyRepo.Claim(req.Context(), keyHash, fingerprint, now.Add(-idempotencyWindow), now.Add(-idempotencyLease))
+		if err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to check Idempotency-Key")
+			return
+		}
+		if stored != nil {
+			switch {
+			case stored.Fingerprint != fingerprint:
+				respondError(w, http.StatusUnprocessableEntity, "Idempotency-Key was already used for a different request")
+			case stored.Status == 0:
+				respondError(w, http.StatusConflict, "A request with this Idempotency-Key is still in progress — retry shortly")
+			default:
+				body, err := openBody(aead, stored.Body)
+				if err != nil {
+					respondError(w, http.StatusInternalServerError, "Failed to replay Idempotency-Key response")
+					return
+				}
+				for name, value := range stored.Header {
+					w.Header().Set(name, value)
+				}
+				w.Header().Set("Idempotent-Replayed", "true")
+				w.WriteHeader(stored.Status)
+				w.Write(body)
+			}
+			return
+		}
+
+		// The client may have gone away; the outcome must still be stored.
+		// Both writes are tied to the claim's token, so that if this request
+		// outlives its lease it cannot touch the claim that replaced it.
+		ctx := context.WithoutCancel(req.Context())
+		release := func() {
+			if err := r.idempotencyRepo.Release(ctx, keyHash, token); err != nil {
+				log.Printf("Failed to release Idempotency-Key: %v", err)
+			}
+		}
+
+		rec := &responseRecorder{ResponseWriter: w}
+		func() {
+			// A panic is answered with a 500 further out, so the key is
+			// released before the panic carries on.
+			defer func() {
+				if p := recover(); p != nil {
+					release()
+					panic(p)
+				}
+			}()
+			next(rec, req)
+		}()
+
+		if rec.status == 0 || rec.status >= 500 {
+			release()
+			return
+		}
+		sealed, err := sealBody(aead, rec.body.Bytes())
+		if err != nil {
+			log.Printf("Failed to seal Idempotency-Key response: %v", err)
+			release()
+			return
+		}
+		resp := &domain.IdempotentResponse{
+			Fingerprint: fingerprint,
+			Status:      rec.status,
+			Header:      make(map[string]string),
+			Body:        sealed,
+		}
+		for _, name := range replayedHeaders {
+			if value := rec.Header().Get(name); value != "" {
+				resp.Header[name] = value
+			}
+		}
+		if err := r.idempotencyRepo.Complete(ctx, keyHash, token, resp); err != nil {
+			log.Printf("Failed to store Idempotency-Key response: %v", err)
+		}
+	}
+}
+
+// RunIdempotencySweeper deletes stored responses older than
+// idempotencyWindow every hour until ctx is done.
+func (r *Router) RunIdempotencySweeper(ctx context.Context) {
+	ticker := time.NewTicker(time.Hour)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := r.idempotencyRepo.DeleteBefore(ctx, time.Now().Add(-idempotencyWindow)); err != nil {
+				log.Printf("Failed to delete expired Idempotency-Keys: %v", err)
+			}
+		}
+	}
+}
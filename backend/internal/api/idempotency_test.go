@@ -0,0 +1,198 @@
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5/middleware"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func postWithKey(r http.Handler, path, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	if key != "" {
+		req.Header.Set(idempotencyHeader, key)
+	}
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestCreateTask_IdempotencyKeyReplaysResponse(t *testing.T) {
+	r, br, tr := newTestRouter()
+	seedBoard(br, testKey, false)
+	path := "/api/boards/" + testKey + "/tasks"
+
+	first := postWithKey(r, path, "retry-1", `{"title":"Once","status":"TODO"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", first.Code)
+	}
+	// Key order and whitespace do not make a different request.
+	retry := postWithKey(r, path, "retry-1", `{ "status": "TODO", "title": "Once" }`)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("expected the first response replayed, got %d: %s", retry.Code, retry.Body.String())
+	}
+	if retry.Header().Get("Idempotent-Replayed") != "true" || retry.Header().Get("ETag") != first.Header().Get("ETag") {
+		t.Errorf("expected replay headers, got %v", retry.Header())
+	}
+	if len(tr.tasks) != 1 {
+		t.Errorf("expected one task, got %d", len(tr.tasks))
+	}
+
+	// A different key, or none, creates another task.
+	postWithKey(r, path, "retry-2", `{"title":"Once","status":"TODO"}`)
+	postWithKey(r, path, "", `{"title":"Once","status":"TODO"}`)
+	if len(tr.tasks) != 3 {
+		t.Errorf("expected three tasks, got %d", len(tr.tasks))
+	}
+}
+
+func TestCreateTask_IdempotencyKeyReusedForDifferentBody_Returns422(t *testing.T) {
+	r, br, tr := newTestRouter()
+	seedBoard(br, testKey, false)
+	path := "/api/boards/" + testKey + "/tasks"
+
+	postWithKey(r, path, "k", `{"title":"First"}`)
+	if rr := postWithKey(r, path, "k", `{"title":"Second"}`); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", rr.Code)
+	}
+	if len(tr.tasks) != 1 {
+		t.Errorf("expected one task, got %d", len(tr.tasks))
+	}
+}
+
+func TestCreateTask_IdempotencyKeyReplaysClientErrors(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	path := "/api/boards/" + testKey + "/tasks"
+
+	first := postWithKey(r, path, "bad", `{"title":""}`)
+	retry := postWithKey(r, path, "bad", `{"title":""}`)
+	if first.Code != http.StatusBadRequest || retry.Code != http.StatusBadRequest || retry.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected the 400 replayed, got %d then %d", first.Code, retry.Code)
+	}
+}
+
+func TestCreateBoard_IdempotencyKey(t *testing.T) {
+	r, br, _ := newTestRouter()
+
+	first := postWithKey(r, "/api/boards", "board-1", `{"title":"Agent run"}`)
+	retry := postWithKey(r, "/api/boards", "board-1", `{"title":"Agent run"}`)
+	if first.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Fatalf("expected the board response replayed, got %d then %d", first.Code, retry.Code)
+	}
+	if len(br.boards) != 1 {
+		t.Errorf("expected one board, got %d", len(br.boards))
+	}
+
+	long := strings.Repeat("k", maxIdempotencyKeyLength+1)
+	if rr := postWithKey(r, "/api/boards", long, `{"title":"Agent run"}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for an overlong key, got %d", rr.Code)
+	}
+}
+
+func TestCreateBoard_IdempotencyKeyStoresSealedResponse(t *testing.T) {
+	r, _, _ := newTestRouter()
+
+	first := postWithKey(r, "/api/boards", "board-1", `{"title":"Once"}`)
+	if first.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", first.Code)
+	}
+	var board domain.Board
+	json.Unmarshal(first.Body.Bytes(), &board)
+
+	// The stored body must not give the board key away.
+	for _, stored := range r.idempotencyRepo.(*mockIdempotencyRepo).records {
+		if bytes.Contains(stored.Body, []byte(board.Key)) {
+			t.Error("expected the stored response not to contain the board key")
+		}
+	}
+
+	retry := postWithKey(r, "/api/boards", "board-1", `{"title":"Once"}`)
+	if retry.Code != http.StatusCreated || retry.Body.String() != first.Body.String() {
+		t.Errorf("expected the first response replayed, got %d: %s", retry.Code, retry.Body.String())
+	}
+}
+
+func TestIdempotencyKey_ReleasedWhenHandlerPanics(t *testing.T) {
+	r, _, _ := newTestRouter()
+	calls := 0
+	handler := middleware.Recoverer(r.idempotent(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		if calls == 1 {
+			panic("boom")
+		}
+		respondJSON(w, http.StatusCreated, map[string]int{"calls": calls})
+	}))
+
+	if rr := postWithKey(handler, "/api/boards", "panic-1", `{}`); rr.Code != http.StatusInternalServerError {
+		t.Fatalf("expected 500 from the panic, got %d", rr.Code)
+	}
+	if rr := postWithKey(handler, "/api/boards", "panic-1", `{}`); rr.Code != http.StatusCreated {
+		t.Errorf("expected the retry to run the handler again, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestIdempotencyKey_AbandonedClaimExpires(t *testing.T) {
+	r, br, tr := newTestRouter()
+	seedBoard(br, testKey, false)
+	path := "/api/boards/" + testKey + "/tasks"
+	body := `{"title":"Once"}`
+
+	// A server that died mid-request leaves its claim behind.
+	repo := r.idempotencyRepo.(*mockIdempotencyRepo)
+	postWithKey(r, path, "stuck", body)
+	for key := range repo.records {
+		repo.records[key].Status = 0
+	}
+	if rr := postWithKey(r, path, "stuck", body); rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409 while the claim is fresh, got %d", rr.Code)
+	}
+
+	for key := range repo.created {
+		repo.created[key] = time.Now().Add(-2 * idempotencyLease)
+	}
+	if rr := postWithKey(r, path, "stuck", body); rr.Code != http.StatusCreated {
+		t.Errorf("expected an abandoned claim to be taken over, got %d", rr.Code)
+	}
+	if len(tr.tasks) != 2 {
+		t.Errorf("expected the retry to create the task, got %d tasks", len(tr.tasks))
+	}
+}
+
+func TestIdempotencyKey_LateRequestKeepsOffTakenOverClaim(t *testing.T) {
+	r, _, _ := newTestRouter()
+	repo := r.idempotencyRepo.(*mockIdempotencyRepo)
+	calls := 0
+	var handler http.HandlerFunc
+	handler = r.idempotent(func(w http.ResponseWriter, req *http.Request) {
+		calls++
+		call := calls
+		if call == 1 {
+			// The first request outlives its lease and a retry takes the
+			// key over and finishes before it does.
+			for key := range repo.created {
+				repo.created[key] = time.Now().Add(-2 * idempotencyLease)
+			}
+			if rr := postWithKey(handler, "/api/boards", "slow", `{}`); rr.Code != http.StatusCreated {
+				t.Fatalf("expected the retry to take over the claim, got %d", rr.Code)
+			}
+		}
+		respondJSON(w, http.StatusCreated, map[string]int{"call": call})
+	})
+
+	postWithKey(handler, "/api/boards", "slow", `{}`)
+	rr := postWithKey(handler, "/api/boards", "slow", `{}`)
+	if rr.Header().Get("Idempotent-Replayed") != "true" {
+		t.Fatalf("expected a replay, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if body := strings.TrimSpace(rr.Body.String()); body != `{"call":2}` {
+		t.Errorf("expected the retry's response to be kept, got %s", body)
+	}
+}
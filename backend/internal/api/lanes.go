@@ -0,0 +1,328 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	maxLanesPerBoard  = 20
+	maxLaneNameLength = 50 // matches tasks.status VARCHAR(50)
+)
+
+type CreateLaneReq struct {
+	Name     domain.TaskStatus `json:"name"`
+	Position *int              `json:"position,omitempty"`
+	WIPLimit int               `json:"wip_limit"`
+}
+
+type UpdateLaneReq struct {
+	Name     *domain.TaskStatus `json:"name,omitempty"`
+	Position *int               `json:"position,omitempty"`
+	WIPLimit *int               `json:"wip_limit,omitempty"`
+}
+
+// validateLaneName returns a user-facing error message, or "" if name is acceptable.
+func validateLaneName(name domain.TaskStatus) string {
+	if strings.TrimSpace(string(name)) == "" {
+		return "Lane name is required"
+	}
+	if strings.TrimSpace(string(name)) != string(name) {
+		return "Lane name must not start or end with whitespace"
+	}
+	if len(name) > maxLaneNameLength {
+		return fmt.Sprintf("Lane name must be %d characters or fewer", maxLaneNameLength)
+	}
+	return ""
+}
+
+// validateLaneNames checks a full set of lane names for a board.
+func validateLaneNames(names []domain.TaskStatus) string {
+	if len(names) > maxLanesPerBoard {
+		return fmt.Sprintf("A board can have at most %d lanes", maxLanesPerBoard)
+	}
+	seen := make(map[domain.TaskStatus]bool, len(names))
+	for _, name := range names {
+		if msg := validateLaneName(name); msg != "" {
+			return msg
+		}
+		if seen[name] {
+			return fmt.Sprintf("Duplicate lane name: %s", name)
+		}
+		seen[name] = true
+	}
+	return ""
+}
+
+// validateWIPLimit returns a user-facing error message, or "" if limit is acceptable.
+func validateWIPLimit(limit int) string {
+	if limit < 0 || limit > maxTasksPerBoard {
+		return fmt.Sprintf("WIP limit must be between 0 (unlimited) and %d", maxTasksPerBoard)
+	}
+	return ""
+}
+
+// wipLimitMessage explains a task rejected by a full lane.
+func wipLimitMessage(status domain.TaskStatus) string {
+	return fmt.Sprintf("Lane %s is at its WIP limit — finish or move a task out of it first", status)
+}
+
+func findLaneByName(lanes []*domain.Lane, name domain.TaskStatus) *domain.Lane {
+	for _, l := range lanes {
+		if l.Name == name {
+			return l
+		}
+	}
+	return nil
+}
+
+// invalidStatusMessage lists the board's lanes for a rejected task status.
+func invalidStatusMessage(lanes []*domain.Lane) string {
+	names := make([]string, len(lanes))
+	for i, l := range lanes {
+		names[i] = string(l.Name)
+	}
+	return "Status must be one of: " + strings.Join(names, ", ")
+}
+
+// finalLane returns the name of the board's last lane, which is treated as
+// "done" when evaluating dependencies.
+func finalLane(lanes []*domain.Lane) domain.TaskStatus {
+	if len(lanes) == 0 {
+		return ""
+	}
+	return lanes[len(lanes)-1].Name
+}
+
+// laneIDs returns the IDs of lanes in order.
+func laneIDs(lanes []*domain.Lane) []uuid.UUID {
+	ids := make([]uuid.UUID, len(lanes))
+	for i, l := range lanes {
+		ids[i] = l.ID
+	}
+	return ids
+}
+
+func (r *Router) handleListLanes(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
+		return
+	}
+	if lanes == nil {
+		lanes = []*domain.Lane{}
+	}
+	respondJSON(w, http.StatusOK, lanes)
+}
+
+func (r *Router) handleCreateLane(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	var reqBody CreateLaneReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if msg := validateLaneName(reqBody.Name); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+	if msg := validateWIPLimit(reqBody.WIPLimit); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
+		return
+	}
+	if len(lanes) >= maxLanesPerBoard {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Sprintf("Lane limit reached (%d)", maxLanesPerBoard))
+		return
+	}
+	if findLaneByName(lanes, reqBody.Name) != nil {
+		respondError(w, http.StatusConflict, "A lane with this name already exists")
+		return
+	}
+
+	lane := &domain.Lane{
+		ID:       uuid.New(),
+		BoardID:  board.ID,
+		Name:     reqBody.Name,
+		Position: len(lanes), // append to end
+		WIPLimit: reqBody.WIPLimit,
+	}
+	if err := r.boardRepo.CreateLane(req.Context(), lane); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create lane")
+		return
+	}
+
+	if reqBody.Position != nil && *reqBody.Position < len(lanes) {
+		ids := moveID(laneIDs(append(lanes, lane)), len(lanes), *reqBody.Position)
+		if err := r.boardRepo.ReorderLanes(req.Context(), board.ID, ids); err != nil {
+			respondError(w, http.StatusInternalServerError, "Failed to reorder lanes")
+			return
+		}
+		lane.Position = indexOfID(ids, lane.ID)
+	}
+
+	if err := r.touchBoard(req, board); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update board")
+		return
+	}
+
+	respondJSON(w, http.StatusCreated, lane)
+}
+
+func (r *Router) handleUpdateLane(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(req, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid lane ID format")
+		return
+	}
+
+	var reqBody UpdateLaneReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
+		return
+	}
+	index := -1
+	for i, l := range lanes {
+		if l.ID == id {
+			index = i
+		}
+	}
+	if index < 0 {
+		respondError(w, http.StatusNotFound, "Lane not found")
+		return
+	}
+	// Every field is checked before anything is written, and the changes are
+	// then stored together, so a rejected request changes nothing.
+	lane := *lanes[index]
+	oldName := lane.Name
+	if reqBody.Name != nil && *reqBody.Name != lane.Name {
+		if msg := validateLaneName(*reqBody.Name); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+		if findLaneByName(lanes, *reqBody.Name) != nil {
+			respondError(w, http.StatusConflict, "A lane with this name already exists")
+			return
+		}
+		lane.Name = *reqBody.Name
+	}
+	if reqBody.WIPLimit != nil {
+		if msg := validateWIPLimit(*reqBody.WIPLimit); msg != "" {
+			respondError(w, http.StatusBadRequest, msg)
+			return
+		}
+		lane.WIPLimit = *reqBody.WIPLimit
+	}
+	var ids []uuid.UUID
+	if reqBody.Position != nil && *reqBody.Position != index {
+		ids = moveID(laneIDs(lanes), index, *reqBody.Position)
+		lane.Position = indexOfID(ids, lane.ID)
+	}
+
+	if err := r.boardRepo.UpdateLane(req.Context(), &lane, oldName, ids); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update lane")
+		return
+	}
+
+	if err := r.touchBoard(req, board); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update board")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, &lane)
+}
+
+func (r *Router) handleDeleteLane(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(req, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid lane ID format")
+		return
+	}
+
+	lanes, err := r.boardRepo.ListLanes(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch lanes")
+		return
+	}
+	var lane *domain.Lane
+	for _, l := range lanes {
+		if l.ID == id {
+			lane = l
+		}
+	}
+	if lane == nil {
+		respondError(w, http.StatusNotFound, "Lane not found")
+		return
+	}
+	if len(lanes) == 1 {
+		respondError(w, http.StatusConflict, "A board must keep at least one lane")
+		return
+	}
+
+	count, err := r.taskRepo.CountByStatus(req.Context(), board.ID, lane.Name)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to count tasks in lane")
+		return
+	}
+	if count > 0 {
+		respondError(w, http.StatusConflict, "Lane still contains tasks, including archived ones — move or delete them before removing it")
+		return
+	}
+
+	if err := r.boardRepo.DeleteLane(req.Context(), lane.ID); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to delete lane")
+		return
+	}
+
+	// Close the gap left by the deleted lane.
+	ids := laneIDs(lanes)
+	i := indexOfID(ids, lane.ID)
+	if err := r.boardRepo.ReorderLanes(req.Context(), board.ID, append(ids[:i], ids[i+1:]...)); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to reorder lanes")
+		return
+	}
+
+	if err := r.touchBoard(req, board); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to update board")
+		return
+	}
+
+	respondJSON(w, http.StatusOK, map[string]string{"message": "deleted"})
+}
@@ -0,0 +1,256 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func laneByName(t *testing.T, br *mockBoardRepo, boardID uuid.UUID, name domain.TaskStatus) *domain.Lane {
+	t.Helper()
+	for _, l := range br.lanes {
+		if l.BoardID == boardID && l.Name == name {
+			return l
+		}
+	}
+	t.Fatalf("lane %q not found", name)
+	return nil
+}
+
+func TestCreateBoard_CustomLanes(t *testing.T) {
+	r, br, _ := newTestRouter()
+	body := `{"title":"Team","lanes":["Backlog","Review","Blocked","Shipped"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/boards", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if len(br.lanes) != 4 {
+		t.Errorf("expected 4 lanes, got %d", len(br.lanes))
+	}
+}
+
+func TestCreateBoard_DuplicateLanes_Returns400(t *testing.T) {
+	r, _, _ := newTestRouter()
+	body := `{"title":"Team","lanes":["Backlog","Backlog"]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/boards", strings.NewReader(body))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestCreateTask_StatusFromCustomLane_Returns201(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	review := &domain.Lane{ID: uuid.New(), BoardID: board.ID, Name: "Review", Position: 3}
+	br.lanes[review.ID] = review
+
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ok","status":"Review"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Errorf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+}
+
+func TestCreateLane_DuplicateName_Returns409(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/lanes", strings.NewReader(`{"name":"TODO"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+}
+
+func TestCreateLane_AtPosition_ShiftsOthers(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/lanes", strings.NewReader(`{"name":"Backlog","position":0}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	var lane domain.Lane
+	if err := json.NewDecoder(rr.Body).Decode(&lane); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if lane.Position != 0 {
+		t.Errorf("expected new lane at position 0, got %d", lane.Position)
+	}
+	if got := laneByName(t, br, board.ID, domain.StatusDone).Position; got != 3 {
+		t.Errorf("expected DONE to shift to position 3, got %d", got)
+	}
+}
+
+func TestUpdateLane_Reorder(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	done := laneByName(t, br, board.ID, domain.StatusDone)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/lanes/"+done.ID.String(), strings.NewReader(`{"position":0}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if done.Position != 0 {
+		t.Errorf("expected DONE at position 0, got %d", done.Position)
+	}
+	if got := laneByName(t, br, board.ID, domain.StatusTodo).Position; got != 1 {
+		t.Errorf("expected TODO at position 1, got %d", got)
+	}
+}
+
+func TestDeleteLane_WithTasks_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	seedTask(tr, board.ID)
+	todo := laneByName(t, br, board.ID, domain.StatusTodo)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/boards/"+testKey+"/lanes/"+todo.ID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+}
+
+func TestDeleteLane_WithArchivedTasks_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	now := time.Now()
+	seedTask(tr, board.ID).ArchivedAt = &now
+	todo := laneByName(t, br, board.ID, domain.StatusTodo)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/boards/"+testKey+"/lanes/"+todo.ID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409 while an archived task is in the lane, got %d", rr.Code)
+	}
+}
+
+func TestDeleteLane_Empty_Returns200(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	done := laneByName(t, br, board.ID, domain.StatusDone)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/boards/"+testKey+"/lanes/"+done.ID.String(), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// DONE is no longer a valid status once its lane is gone.
+	req = httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"ok","status":"DONE"}`))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for removed lane, got %d", rr.Code)
+	}
+}
+
+// ─── WIP limits ──────────────────────────────────────────────────────────────
+
+func TestUpdateLane_SetWIPLimit(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	lane := laneByName(t, br, board.ID, domain.StatusInProgress)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/lanes/"+lane.ID.String(), strings.NewReader(`{"wip_limit":2}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var got domain.Lane
+	if err := json.NewDecoder(rr.Body).Decode(&got); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if got.WIPLimit != 2 || lane.WIPLimit != 2 {
+		t.Errorf("expected WIP limit 2, got %d", got.WIPLimit)
+	}
+}
+
+func TestUpdateLane_NegativeWIPLimit_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	lane := laneByName(t, br, board.ID, domain.StatusInProgress)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/lanes/"+lane.ID.String(), strings.NewReader(`{"wip_limit":-1}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestUpdateLane_InvalidField_WritesNothing(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	lane := laneByName(t, br, board.ID, domain.StatusInProgress)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/lanes/"+lane.ID.String(), strings.NewReader(`{"name":"DOING","wip_limit":-1,"position":0}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+	if lane.Name != domain.StatusInProgress || lane.Position != 1 {
+		t.Errorf("expected lane to be unchanged, got %q at position %d", lane.Name, lane.Position)
+	}
+}
+
+func TestUpdateTask_MoveIntoFullLane_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	laneByName(t, br, board.ID, domain.StatusInProgress).WIPLimit = 1
+
+	busy := seedTask(tr, board.ID)
+	busy.Status = domain.StatusInProgress
+	waiting := seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+waiting.ID.String(), strings.NewReader(`{"status":"IN_PROGRESS"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Fatalf("expected 409, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	// Finishing the busy task frees the slot.
+	busy.Status = domain.StatusDone
+	req = httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+waiting.ID.String(), strings.NewReader(`{"status":"IN_PROGRESS"}`))
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Errorf("expected 200 once the lane has room, got %d", rr.Code)
+	}
+}
+
+func TestCreateTask_IntoFullLane_Returns409(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	laneByName(t, br, board.ID, domain.StatusTodo).WIPLimit = 1
+	seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":"one too many"}`))
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,134 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+)
+
+const (
+	// defaultBoardTTL is the lifetime of a board created without ttl_seconds,
+	// unless the server's maximum is shorter.
+	defaultBoardTTL = 7 * 24 * time.Hour
+	// minBoardTTL is the shortest lifetime a board can be given.
+	minBoardTTL = time.Hour
+)
+
+// ExtendBoardReq sets a board's lifetime from now. Without ttl_seconds the
+// board's current lifetime is reused. The new expiry may not be earlier than
+// the current one.
+type ExtendBoardReq struct {
+	TTLSeconds *int `json:"ttl_seconds,omitempty"`
+}
+
+// defaultTTL returns the lifetime for a board created without ttl_seconds.
+func (r *Router) defaultTTL() time.Duration {
+	return min(defaultBoardTTL, r.maxBoardTTL)
+}
+
+// validateTTL returns an error message if ttlSeconds is outside the allowed
+// range, or "" if it is valid.
+func (r *Router) validateTTL(ttlSeconds int) string {
+	// Compare whole seconds: converting first could overflow a Duration and
+	// wrap a huge value into range.
+	minSeconds, maxSeconds := int(minBoardTTL.Seconds()), int(r.maxBoardTTL.Seconds())
+	if ttlSeconds < minSeconds || ttlSeconds > maxSeconds {
+		return fmt.Sprintf("ttl_seconds must be between %d and %d", minSeconds, maxSeconds)
+	}
+	return ""
+}
+
+func (r *Router) handleExtendBoard(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	var reqBody ExtendBoardReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil && !errors.Is(err, io.EOF) {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+
+	ttlSeconds := board.TTLSeconds
+	if reqBody.TTLSeconds != nil {
+		ttlSeconds = *reqBody.TTLSeconds
+	}
+	if msg := r.validateTTL(ttlSeconds); msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(ttlSeconds) * time.Second)
+	if expiresAt.Before(board.ExpiresAt) {
+		respondError(w, http.StatusBadRequest, "ttl_seconds would shorten the board's remaining lifetime")
+		return
+	}
+	board.TTLSeconds = ttlSeconds
+	board.ExpiresAt = expiresAt
+	board.UpdatedAt = now
+	if err := r.boardRepo.Update(req.Context(), board); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to extend board")
+		return
+	}
+	r.publish(board.ID, eventBoardUpdated, board)
+
+	respondJSON(w, http.StatusOK, board)
+}
+
+// statusRecorder notes the status of a response passing through it.
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (rec *statusRecorder) WriteHeader(status int) {
+	rec.status = status
+	rec.ResponseWriter.WriteHeader(status)
+}
+
+func (rec *statusRecorder) Write(b []byte) (int, error) {
+	if rec.status == 0 {
+		rec.status = http.StatusOK
+	}
+	return rec.ResponseWriter.Write(b)
+}
+
+// slideExpiry pushes back the expiry of boards with sliding expiry after
+// every successful change made through a {key} route. Reads do not count as
+// activity, and are passed through untouched so that streaming and socket
+// upgrades keep their underlying writer.
+func (r *Router) slideExpiry(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		switch req.Method {
+		case http.MethodGet, http.MethodHead, http.MethodOptions:
+			next.ServeHTTP(w, req)
+			return
+		}
+
+		rec := &statusRecorder{ResponseWriter: w}
+		next.ServeHTTP(rec, req)
+
+		// The route's URL parameters are only known once it has been matched.
+		key := chi.URLParam(req, "key")
+		if key == "" || rec.status < 200 || rec.status >= 300 {
+			return
+		}
+		r.slideBoardExpiry(req, key)
+	})
+}
+
+// slideBoardExpiry slides the expiry of the board with this key, logging
+// failures rather than failing a change that has already been made.
+func (r *Router) slideBoardExpiry(req *http.Request, key string) {
+	if err := r.boardRepo.SlideExpiry(req.Context(), key, time.Now()); err != nil {
+		log.Printf("Failed to slide board expiry: %v", err)
+	}
+}
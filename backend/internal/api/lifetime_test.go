@@ -0,0 +1,135 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func createBoard(t *testing.T, r http.Handler, body string) (*httptest.ResponseRecorder, domain.Board) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards", strings.NewReader(body)))
+	var board domain.Board
+	json.NewDecoder(rr.Body).Decode(&board)
+	return rr, board
+}
+
+func extendBoard(r http.Handler, body string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/extend", strings.NewReader(body)))
+	return rr
+}
+
+func TestCreateBoard_TTL(t *testing.T) {
+	r, _, _ := newTestRouter()
+
+	rr, board := createBoard(t, r, `{"title":"Default"}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rr.Code)
+	}
+	if board.TTLSeconds != 7*24*3600 || board.SlidingExpiry {
+		t.Errorf("expected a one-week fixed lifetime, got %d sliding=%v", board.TTLSeconds, board.SlidingExpiry)
+	}
+
+	rr, board = createBoard(t, r, `{"title":"Long job","ttl_seconds":1209600,"sliding_expiry":true}`)
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d", rr.Code)
+	}
+	if d := time.Until(board.ExpiresAt); d < 13*24*time.Hour || d > 14*24*time.Hour {
+		t.Errorf("expected expiry in 14 days, got %v", d)
+	}
+	if !board.SlidingExpiry {
+		t.Error("expected sliding expiry")
+	}
+
+	// 18446747674 seconds overflows a Duration and wraps to about an hour.
+	for _, ttl := range []string{"0", "60", "2592001", "18446744074", "18446747674"} {
+		if rr, _ := createBoard(t, r, `{"title":"x","ttl_seconds":`+ttl+`}`); rr.Code != http.StatusBadRequest {
+			t.Errorf("ttl_seconds %s: expected 400, got %d", ttl, rr.Code)
+		}
+	}
+}
+
+func TestExtendBoard(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	board.ExpiresAt = time.Now().Add(time.Hour)
+
+	// Without a body the board's own lifetime is renewed.
+	if rr := extendBoard(r, ""); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if d := time.Until(br.boards[testKey].ExpiresAt); d < 6*24*time.Hour {
+		t.Errorf("expected expiry renewed to a week, got %v", d)
+	}
+
+	// Extending never brings the expiry forward.
+	if rr := extendBoard(r, `{"ttl_seconds":7200}`); rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a shorter lifetime, got %d", rr.Code)
+	}
+	board.ExpiresAt = time.Now().Add(time.Hour)
+	if rr := extendBoard(r, `{"ttl_seconds":7200}`); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	got := br.boards[testKey]
+	if got.TTLSeconds != 7200 || time.Until(got.ExpiresAt) > 2*time.Hour {
+		t.Errorf("expected a two-hour lifetime, got %d expiring in %v", got.TTLSeconds, time.Until(got.ExpiresAt))
+	}
+
+	for _, ttl := range []string{"99999999", "18446747674"} {
+		if rr := extendBoard(r, `{"ttl_seconds":`+ttl+`}`); rr.Code != http.StatusBadRequest {
+			t.Errorf("ttl_seconds %s: expected 400 above the maximum, got %d", ttl, rr.Code)
+		}
+	}
+}
+
+func TestExtendBoard_Expired_Returns410(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, true)
+
+	if rr := extendBoard(r, ""); rr.Code != http.StatusGone {
+		t.Errorf("expected 410, got %d", rr.Code)
+	}
+}
+
+func TestSlidingExpiry(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	soon := time.Now().Add(time.Hour)
+	board.ExpiresAt = soon
+
+	// Reads are not activity, and fixed boards never slide.
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey, nil))
+	createTasks(t, r, "TODO", "a")
+	if !board.ExpiresAt.Equal(soon) {
+		t.Fatalf("expected a fixed expiry, got %v", board.ExpiresAt)
+	}
+
+	board.SlidingExpiry = true
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey, nil))
+	if !board.ExpiresAt.Equal(soon) {
+		t.Fatal("expected a read not to slide the expiry")
+	}
+
+	// A failed change does not slide it either.
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(`{"title":""}`)))
+	if rr.Code != http.StatusBadRequest || !board.ExpiresAt.Equal(soon) {
+		t.Fatalf("expected a rejected create to leave the expiry, got %d", rr.Code)
+	}
+
+	before := board.UpdatedAt
+	createTasks(t, r, "TODO", "b")
+	if d := time.Until(board.ExpiresAt); d < 6*24*time.Hour {
+		t.Errorf("expected the expiry pushed to a week out, got %v", d)
+	}
+	if !board.UpdatedAt.After(before) {
+		t.Error("expected sliding the expiry to bump updated_at, so board ETags change")
+	}
+}
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+// MoveTaskReq is the body of POST /tasks/{id}/move and the task payload of a
+// move operation. Status is required; at most one of Position, Before and
+// After places the task in that lane, and with none it goes to the end.
+type MoveTaskReq struct {
+	Status   domain.TaskStatus `json:"status"`
+	Position *int              `json:"position,omitempty"`
+	Before   *uuid.UUID        `json:"before,omitempty"`
+	After    *uuid.UUID        `json:"after,omitempty"`
+}
+
+// neighbourMessage rejects a before/after task that is not in the target lane.
+func neighbourMessage(status domain.TaskStatus) string {
+	return fmt.Sprintf("before/after must be an unarchived task in lane %s", status)
+}
+
+// moveUpdate turns a move request into the update it implies and, when
+// Before or After is set, the neighbour to place the task next to. It returns
+// a user-facing error message, or "" on success.
+func moveUpdate(taskID uuid.UUID, reqBody MoveTaskReq) (UpdateTaskReq, *domain.Neighbour, string) {
+	if reqBody.Status == "" {
+		return UpdateTaskReq{}, nil, "status is required for move"
+	}
+	given := 0
+	for _, set := range []bool{reqBody.Position != nil, reqBody.Before != nil, reqBody.After != nil} {
+		if set {
+			given++
+		}
+	}
+	if given > 1 {
+		return UpdateTaskReq{}, nil, "Give at most one of position, before and after"
+	}
+
+	update := UpdateTaskReq{Status: &reqBody.Status, Position: reqBody.Position}
+	if update.Position == nil {
+		end := endOfLane
+		update.Position = &end
+	}
+
+	var neighbour *domain.Neighbour
+	switch {
+	case reqBody.Before != nil:
+		neighbour = &domain.Neighbour{ID: *reqBody.Before}
+	case reqBody.After != nil:
+		neighbour = &domain.Neighbour{ID: *reqBody.After, After: true}
+	}
+	if neighbour != nil && neighbour.ID == taskID {
+		return UpdateTaskReq{}, nil, "A task cannot be placed next to itself"
+	}
+	return update, neighbour, ""
+}
+
+// validNeighbour reports whether other can anchor a task moving into status.
+func validNeighbour(task, other *domain.Task, status domain.TaskStatus) bool {
+	return other != nil && other.BoardID == task.BoardID && other.ArchivedAt == nil && other.Status == status
+}
+
+// resolveMove is moveUpdate plus a check that the neighbour is currently in
+// the target lane. The repository checks again when it places the task.
+func (r *Router) resolveMove(ctx context.Context, task *domain.Task, reqBody MoveTaskReq) (UpdateTaskReq, *domain.Neighbour, string) {
+	update, neighbour, msg := moveUpdate(task.ID, reqBody)
+	if msg != "" || neighbour == nil {
+		return update, neighbour, msg
+	}
+	other, err := r.taskRepo.GetByID(ctx, neighbour.ID)
+	if err != nil || !validNeighbour(task, other, reqBody.Status) {
+		return UpdateTaskReq{}, nil, neighbourMessage(reqBody.Status)
+	}
+	return update, neighbour, ""
+}
+
+// handleMoveTask moves a task into a lane, directly before or after another
+// task there, at an index, or at the end. The task is given a rank between
+// its new neighbours while the board's lock is held, so other tasks keep
+// their ranks and concurrent moves cannot compute the same one.
+func (r *Router) handleMoveTask(w http.ResponseWriter, req *http.Request) {
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+	if task.ArchivedAt != nil {
+		respondError(w, http.StatusConflict, archivedTaskMessage)
+		return
+	}
+	if !checkIfMatch(w, req, task) {
+		return
+	}
+
+	var reqBody MoveTaskReq
+	if err := json.NewDecoder(req.Body).Decode(&reqBody); err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	update, neighbour, msg := r.resolveMove(req.Context(), task, reqBody)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if status, msg := r.updateTask(req, board, task, update, neighbour); msg != "" {
+		respondError(w, status, msg)
+		return
+	}
+	respondTask(w, http.StatusOK, task)
+}
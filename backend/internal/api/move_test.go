@@ -0,0 +1,209 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+// createTasks adds tasks with the given titles through the API so that they
+// get their ranks from the repository, and returns their IDs.
+func createTasks(t *testing.T, r http.Handler, status string, titles ...string) []uuid.UUID {
+	t.Helper()
+	var ids []uuid.UUID
+	for _, title := range titles {
+		rr := httptest.NewRecorder()
+		body := `{"title":"` + title + `","status":"` + status + `"}`
+		r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks", strings.NewReader(body)))
+		if rr.Code != http.StatusCreated {
+			t.Fatalf("create %s: expected 201, got %d", title, rr.Code)
+		}
+		var task domain.Task
+		json.NewDecoder(rr.Body).Decode(&task)
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
+
+// laneTitles returns the titles in a lane in rank order, failing unless the
+// positions are exactly 0..n-1.
+func laneTitles(t *testing.T, tr *mockTaskRepo, boardID uuid.UUID, status domain.TaskStatus) string {
+	t.Helper()
+	lane := tr.laneOrder(boardID, status, uuid.Nil)
+	titles := make([]string, len(lane))
+	for i, task := range lane {
+		if task.Position != i {
+			t.Errorf("%s: expected position %d, got %d", task.Title, i, task.Position)
+		}
+		titles[i] = task.Title
+	}
+	return strings.Join(titles, ",")
+}
+
+func moveTask(r http.Handler, id uuid.UUID, body string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	path := "/api/boards/" + testKey + "/tasks/" + id.String() + "/move"
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, path, strings.NewReader(body)))
+	return rr
+}
+
+func TestMoveTask_BeforeAndAfter(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	todo := createTasks(t, r, "TODO", "a", "b", "c", "d")
+	done := createTasks(t, r, "DONE", "x", "y")
+
+	// Within a lane: move a after c.
+	if rr := moveTask(r, todo[0], `{"status":"TODO","after":"`+todo[2].String()+`"}`); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "b,c,a,d" {
+		t.Errorf("expected b,c,a,d, got %s", got)
+	}
+
+	// Across lanes: move d before y, closing the gap it leaves.
+	rr := moveTask(r, todo[3], `{"status":"DONE","before":"`+done[1].String()+`"}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var moved domain.Task
+	json.NewDecoder(rr.Body).Decode(&moved)
+	if moved.Status != "DONE" || moved.Position != 1 {
+		t.Errorf("expected DONE at position 1, got %s at %d", moved.Status, moved.Position)
+	}
+	if got := laneTitles(t, tr, board.ID, "DONE"); got != "x,d,y" {
+		t.Errorf("expected x,d,y, got %s", got)
+	}
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "b,c,a" {
+		t.Errorf("expected b,c,a, got %s", got)
+	}
+
+	// Neither neighbour nor position: to the end of the lane.
+	moveTask(r, todo[1], `{"status":"TODO"}`)
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "c,a,b" {
+		t.Errorf("expected c,a,b, got %s", got)
+	}
+}
+
+func TestMoveTask_Rejected(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	todo := createTasks(t, r, "TODO", "a", "b")
+	done := createTasks(t, r, "DONE", "x")
+
+	cases := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"no status", `{"before":"` + todo[1].String() + `"}`, http.StatusBadRequest},
+		{"two anchors", `{"status":"TODO","before":"` + todo[1].String() + `","position":0}`, http.StatusBadRequest},
+		{"itself", `{"status":"TODO","after":"` + todo[0].String() + `"}`, http.StatusBadRequest},
+		{"neighbour in another lane", `{"status":"TODO","after":"` + done[0].String() + `"}`, http.StatusBadRequest},
+		{"unknown neighbour", `{"status":"TODO","after":"` + uuid.NewString() + `"}`, http.StatusBadRequest},
+		{"unknown lane", `{"status":"NOPE"}`, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		if rr := moveTask(r, todo[0], c.body); rr.Code != c.status {
+			t.Errorf("%s: expected %d, got %d", c.name, c.status, rr.Code)
+		}
+	}
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "a,b" {
+		t.Errorf("expected the lane unchanged, got %s", got)
+	}
+}
+
+func TestMoveTask_RewritesOnlyTheMovedRank(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	ids := createTasks(t, r, "TODO", "a", "b", "c")
+	before := make(map[uuid.UUID]string)
+	for _, id := range ids {
+		before[id] = tr.tasks[id].Rank
+	}
+
+	if rr := moveTask(r, ids[2], `{"status":"TODO","before":"`+ids[0].String()+`"}`); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "c,a,b" {
+		t.Errorf("expected c,a,b, got %s", got)
+	}
+	for _, id := range ids[:2] {
+		if tr.tasks[id].Rank != before[id] || tr.tasks[id].Version != 1 {
+			t.Errorf("%s: expected its rank and version untouched", tr.tasks[id].Title)
+		}
+	}
+	if tr.tasks[ids[2]].Rank == before[ids[2]] {
+		t.Error("expected the moved task to get a new rank")
+	}
+}
+
+func TestTaskPositions_StayDense(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	ids := createTasks(t, r, "TODO", "a", "b", "c")
+
+	// Archiving closes the gap; restoring appends.
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodDelete, "/api/boards/"+testKey+"/tasks/"+ids[0].String(), nil))
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "b,c" {
+		t.Errorf("expected b,c after archiving, got %s", got)
+	}
+	createTasks(t, r, "TODO", "d")
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks/"+ids[0].String()+"/restore", nil))
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "b,c,d,a" {
+		t.Errorf("expected b,c,d,a after restoring, got %s", got)
+	}
+
+	// PUT to another lane without a position appends there.
+	createTasks(t, r, "DONE", "x")
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+ids[1].String(), strings.NewReader(`{"status":"DONE"}`)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if got := laneTitles(t, tr, board.ID, "DONE"); got != "x,b" {
+		t.Errorf("expected x,b, got %s", got)
+	}
+
+	// An out-of-range position is clamped to the end.
+	r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPut, "/api/boards/"+testKey+"/tasks/"+ids[2].String(), strings.NewReader(`{"position":99}`)))
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "d,a,c" {
+		t.Errorf("expected d,a,c, got %s", got)
+	}
+}
+
+func TestBatch_MoveWithNeighbour(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	ids := createTasks(t, r, "TODO", "a", "b", "c")
+
+	body := `{"operations":[
+		{"op":"move","id":"` + ids[2].String() + `","task":{"status":"TODO","before":"` + ids[0].String() + `"}},
+		{"op":"move","id":"` + ids[0].String() + `","task":{"status":"DONE"}}
+	]}`
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/batch", strings.NewReader(body)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "c,b" {
+		t.Errorf("expected c,b, got %s", got)
+	}
+
+	// A neighbour moved away earlier in the batch is rejected.
+	body = `{"operations":[
+		{"op":"move","id":"` + ids[1].String() + `","task":{"status":"DONE"}},
+		{"op":"move","id":"` + ids[2].String() + `","task":{"status":"TODO","after":"` + ids[1].String() + `"}}
+	]}`
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/batch", strings.NewReader(body)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", rr.Code)
+	}
+}
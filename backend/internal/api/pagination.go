@@ -0,0 +1,110 @@
+package api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+// maxPageSize caps ?limit= on board reads.
+const maxPageSize = maxTasksPerBoard
+
+const invalidCursorMessage = "cursor is invalid — start again without it"
+
+// selectableFields are the task properties that ?fields= can select.
+var selectableFields = []string{
+	"id", "title", "description", "status", "rank", "position", "labels", "assignee", "due_at",
+	"priority", "archived_at", "created_at", "updated_at", "version", "checklist", "blocked_by", "blocks",
+}
+
+// pageCursor is what a client's opaque cursor decodes to. Sort ties it to the
+// ordering it was issued for, since a cursor means nothing in another one.
+type pageCursor struct {
+	Sort domain.TaskSort
+	domain.TaskCursor
+}
+
+func encodeCursor(sort domain.TaskSort, c domain.TaskCursor) string {
+	data, _ := json.Marshal(pageCursor{Sort: sort, TaskCursor: c})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(s string, sort domain.TaskSort) (*domain.TaskCursor, string) {
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, invalidCursorMessage
+	}
+	var c pageCursor
+	if err := json.Unmarshal(data, &c); err != nil {
+		return nil, invalidCursorMessage
+	}
+	if c.Sort != sort {
+		return nil, "cursor was issued for a different sort"
+	}
+	return &c.TaskCursor, ""
+}
+
+// parsePage reads ?limit= and ?cursor= into filter.
+func parsePage(query url.Values, filter *domain.TaskFilter) string {
+	if raw := query.Get("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 1 || limit > maxPageSize {
+			return "limit must be between 1 and " + strconv.Itoa(maxPageSize)
+		}
+		filter.Limit = limit
+	}
+	if raw := query.Get("cursor"); raw != "" {
+		after, msg := decodeCursor(raw, filter.Sort)
+		if msg != "" {
+			return msg
+		}
+		filter.After = after
+	}
+	return ""
+}
+
+// parseFields reads ?fields=, given as a comma-separated list or repeated.
+// The ID is always included. A nil result means every field.
+func parseFields(query url.Values) ([]string, string) {
+	values, ok := query["fields"]
+	if !ok {
+		return nil, ""
+	}
+	fields := []string{"id"}
+	for _, v := range values {
+		for _, f := range strings.Split(v, ",") {
+			f = strings.TrimSpace(f)
+			if !slices.Contains(selectableFields, f) {
+				return nil, "fields must be a comma-separated list of: " + strings.Join(selectableFields, ", ")
+			}
+			if !slices.Contains(fields, f) {
+				fields = append(fields, f)
+			}
+		}
+	}
+	return fields, ""
+}
+
+// selectFields returns each task's JSON form cut down to fields. Fields a
+// task omits, such as an empty checklist, stay omitted.
+func selectFields(tasks []*domain.Task, fields []string) []map[string]json.RawMessage {
+	selected := make([]map[string]json.RawMessage, len(tasks))
+	for i, task := range tasks {
+		data, _ := json.Marshal(task)
+		var all map[string]json.RawMessage
+		json.Unmarshal(data, &all)
+
+		selected[i] = make(map[string]json.RawMessage, len(fields))
+		for _, f := range fields {
+			if v, ok := all[f]; ok {
+				selected[i][f] = v
+			}
+		}
+	}
+	return selected
+}
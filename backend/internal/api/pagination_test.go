@@ -0,0 +1,142 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func getBoardPage(t *testing.T, r http.Handler, query string) (int, map[string]json.RawMessage) {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"?"+query, nil))
+	var body map[string]json.RawMessage
+	json.NewDecoder(rr.Body).Decode(&body)
+	return rr.Code, body
+}
+
+func TestGetBoard_PagesThroughTasks(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	createTasks(t, r, "DONE", "d1")
+	createTasks(t, r, "TODO", "t1", "t2", "t3")
+	createTasks(t, r, "IN_PROGRESS", "p1")
+
+	var titles []string
+	query, pages := "limit=2", 0
+	for {
+		code, body := getBoardPage(t, r, query)
+		if code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+		var tasks []struct{ Title string }
+		json.Unmarshal(body["tasks"], &tasks)
+		for _, task := range tasks {
+			titles = append(titles, task.Title)
+		}
+		pages++
+
+		var cursor string
+		json.Unmarshal(body["next_cursor"], &cursor)
+		if cursor == "" {
+			break
+		}
+		query = "limit=2&cursor=" + url.QueryEscape(cursor)
+	}
+
+	if got := strings.Join(titles, ","); got != "t1,t2,t3,p1,d1" || pages != 3 {
+		t.Errorf("expected t1,t2,t3,p1,d1 over 3 pages, got %s over %d", got, pages)
+	}
+}
+
+func TestGetBoard_FieldsAndStatus(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	createTasks(t, r, "TODO", "a")
+	createTasks(t, r, "DONE", "b")
+
+	code, body := getBoardPage(t, r, "fields=title,status&status=DONE")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	var tasks []map[string]interface{}
+	json.Unmarshal(body["tasks"], &tasks)
+	if len(tasks) != 1 || tasks[0]["title"] != "b" {
+		t.Fatalf("expected only task b, got %v", tasks)
+	}
+	if len(tasks[0]) != 3 || tasks[0]["id"] == nil || tasks[0]["status"] != "DONE" {
+		t.Errorf("expected only id, title and status, got %v", tasks[0])
+	}
+	if body["title"] == nil || body["assignees"] == nil {
+		t.Error("expected the board itself to be unaffected")
+	}
+}
+
+func TestGetBoard_InvalidPageParams_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	createTasks(t, r, "TODO", "a", "b")
+
+	_, body := getBoardPage(t, r, "limit=1")
+	var cursor string
+	json.Unmarshal(body["next_cursor"], &cursor)
+	if cursor == "" {
+		t.Fatal("expected a next cursor")
+	}
+
+	for _, query := range []string{
+		"limit=0",
+		"limit=101",
+		"limit=x",
+		"cursor=not-a-cursor",
+		"sort=priority&cursor=" + url.QueryEscape(cursor),
+		"fields=title,secret",
+		"status=NOPE",
+	} {
+		if code, _ := getBoardPage(t, r, query); code != http.StatusBadRequest {
+			t.Errorf("%s: expected 400, got %d", query, code)
+		}
+	}
+}
+
+func TestGetBoard_ETagCoversPageParameters(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	createTasks(t, r, "TODO", "a", "b")
+
+	get := func(query, ifNoneMatch string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"?"+query, nil)
+		req.Header.Set("If-None-Match", ifNoneMatch)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr
+	}
+
+	etag := get("fields=id", "").Header().Get("ETag")
+	if rr := get("fields=id", etag); rr.Code != http.StatusNotModified {
+		t.Fatalf("expected 304 for the same page, got %d", rr.Code)
+	}
+	// The same tasks under different parameters are a different response.
+	for _, query := range []string{"fields=id,title", "", "limit=2"} {
+		if rr := get(query, etag); rr.Code != http.StatusOK {
+			t.Errorf("%q: expected 200, got %d", query, rr.Code)
+		}
+	}
+
+	// A task past the end of the page adds a next cursor.
+	etag = get("limit=2", "").Header().Get("ETag")
+	ids := createTasks(t, r, "DONE", "c")
+	if rr := get("limit=2", etag); rr.Code != http.StatusOK {
+		t.Errorf("expected 200 once a next page exists, got %d", rr.Code)
+	}
+
+	// So does an assignee on a task off the page.
+	etag = get("limit=2", "").Header().Get("ETag")
+	doRequest(r, http.MethodPut, testKey+"/tasks/"+ids[0].String(), `{"assignee":"agent-1"}`)
+	if rr := get("limit=2", etag); rr.Code != http.StatusOK {
+		t.Errorf("expected 200 after a new assignee, got %d", rr.Code)
+	}
+}
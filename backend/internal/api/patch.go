@@ -0,0 +1,168 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"slices"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+	"github.com/zeeshanejaz/kanbin/backend/internal/jsonpatch"
+)
+
+// Media types accepted by PATCH /boards/{key}/tasks/{id}.
+const (
+	mergePatchType = "application/merge-patch+json"
+	jsonPatchType  = "application/json-patch+json"
+)
+
+// patchableFields are the task fields a PATCH may change. Removing one, or
+// setting it to null, sets it to its cleared value; fields without one
+// cannot be removed.
+var patchableFields = []string{"title", "description", "status", "position", "labels", "assignee", "due_at", "priority"}
+
+var clearedValues = map[string]interface{}{
+	"description": "",
+	"labels":      []interface{}{},
+	"assignee":    "",
+	"due_at":      "",
+	"priority":    "",
+}
+
+// handlePatchTask applies a JSON Merge Patch or JSON Patch to the task's JSON
+// form. The changed fields are then validated and stored as PUT would store
+// them, so PATCH and PUT share every rule. JSON Patch test operations make
+// the whole patch conditional on the task's current state.
+func (r *Router) handlePatchTask(w http.ResponseWriter, req *http.Request) {
+	mediaType, _, _ := mime.ParseMediaType(req.Header.Get("Content-Type"))
+	if mediaType != mergePatchType && mediaType != jsonPatchType {
+		w.Header().Set("Accept-Patch", mergePatchType+", "+jsonPatchType)
+		respondError(w, http.StatusUnsupportedMediaType, "Content-Type must be "+mergePatchType+" or "+jsonPatchType)
+		return
+	}
+
+	task, board := r.taskFromPath(w, req)
+	if task == nil {
+		return
+	}
+	if task.ArchivedAt != nil {
+		respondError(w, http.StatusConflict, archivedTaskMessage)
+		return
+	}
+	if !checkIfMatch(w, req, task) {
+		return
+	}
+
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	before, err := taskDocument(task)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to patch task")
+		return
+	}
+	after, _ := taskDocument(task)
+
+	var patched interface{}
+	if mediaType == mergePatchType {
+		var patch interface{}
+		if err := json.Unmarshal(body, &patch); err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid request body")
+			return
+		}
+		patched = jsonpatch.MergePatch(after, patch)
+	} else {
+		ops, err := jsonpatch.Parse(body)
+		if err == nil {
+			patched, err = jsonpatch.Apply(after, ops)
+		}
+		switch {
+		case errors.Is(err, jsonpatch.ErrTestFailed):
+			respondError(w, http.StatusConflict, "Patch test failed: "+err.Error())
+			return
+		case errors.Is(err, jsonpatch.ErrInvalidPatch):
+			respondError(w, http.StatusBadRequest, err.Error())
+			return
+		case err != nil:
+			respondError(w, http.StatusUnprocessableEntity, "Patch cannot be applied: "+err.Error())
+			return
+		}
+	}
+
+	doc, ok := patched.(map[string]interface{})
+	if !ok {
+		respondError(w, http.StatusUnprocessableEntity, "Patch must leave the task a JSON object")
+		return
+	}
+	reqBody, msg := patchedUpdate(before, doc)
+	if msg != "" {
+		respondError(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	if status, msg := r.updateTask(req, board, task, reqBody, nil); msg != "" {
+		respondError(w, status, msg)
+		return
+	}
+	respondTask(w, http.StatusOK, task)
+}
+
+// taskDocument returns the task's JSON form as a patchable value.
+func taskDocument(task *domain.Task) (map[string]interface{}, error) {
+	data, err := json.Marshal(task)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]interface{}
+	return doc, json.Unmarshal(data, &doc)
+}
+
+// patchedUpdate turns the difference between a task's document before and
+// after patching into the UpdateTaskReq that PUT would receive. It rejects
+// changes to read-only and unknown fields.
+func patchedUpdate(before, after map[string]interface{}) (UpdateTaskReq, string) {
+	var reqBody UpdateTaskReq
+	for _, doc := range []map[string]interface{}{before, after} {
+		for field := range doc {
+			if slices.Contains(patchableFields, field) || reflect.DeepEqual(before[field], after[field]) {
+				continue
+			}
+			if !slices.Contains(selectableFields, field) {
+				return reqBody, "Unknown task field: " + field
+			}
+			return reqBody, fmt.Sprintf("%s is read-only", field)
+		}
+	}
+
+	changed := make(map[string]interface{})
+	for _, field := range patchableFields {
+		v, ok := after[field]
+		if ok && reflect.DeepEqual(before[field], v) {
+			continue
+		}
+		if v == nil {
+			cleared, ok := clearedValues[field]
+			if !ok {
+				return reqBody, fmt.Sprintf("%s cannot be removed", field)
+			}
+			v = cleared
+		}
+		changed[field] = v
+	}
+
+	data, _ := json.Marshal(changed)
+	if err := json.Unmarshal(data, &reqBody); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) {
+			return reqBody, "Invalid value for " + typeErr.Field
+		}
+		return reqBody, "Invalid request body"
+	}
+	return reqBody, ""
+}
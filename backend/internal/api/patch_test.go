@@ -0,0 +1,123 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func patchTask(r http.Handler, task *domain.Task, contentType, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPatch, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestPatchTask_MergePatch(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	due := time.Now().Add(time.Hour)
+	task.Assignee, task.DueAt, task.Labels, task.Description = "ada", &due, []string{"bug"}, "keep me"
+
+	rr := patchTask(r, task, mergePatchType, `{"title":"Renamed","assignee":null,"due_at":null,"labels":["infra"]}`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	stored := tr.tasks[task.ID]
+	if stored.Title != "Renamed" || stored.Assignee != "" || stored.DueAt != nil {
+		t.Errorf("expected title set and assignee and due date cleared, got %+v", stored)
+	}
+	if len(stored.Labels) != 1 || stored.Labels[0] != "infra" || stored.Description != "keep me" {
+		t.Errorf("expected labels replaced and description untouched, got %+v", stored)
+	}
+	if rr.Header().Get("ETag") != `"v2"` {
+		t.Errorf("expected ETag \"v2\", got %s", rr.Header().Get("ETag"))
+	}
+}
+
+func TestPatchTask_JSONPatch(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	task.Labels = []string{"a", "b"}
+
+	rr := patchTask(r, task, jsonPatchType, `[
+		{"op":"test","path":"/status","value":"TODO"},
+		{"op":"remove","path":"/labels/0"},
+		{"op":"add","path":"/labels/-","value":"c"},
+		{"op":"replace","path":"/status","value":"DONE"}
+	]`)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	stored := tr.tasks[task.ID]
+	if stored.Status != "DONE" || strings.Join(stored.Labels, ",") != "b,c" {
+		t.Errorf("expected DONE with labels b,c, got %s with %v", stored.Status, stored.Labels)
+	}
+
+	// A failed test applies nothing.
+	rr = patchTask(r, stored, jsonPatchType, `[
+		{"op":"replace","path":"/title","value":"Nope"},
+		{"op":"test","path":"/version","value":1}
+	]`)
+	if rr.Code != http.StatusConflict {
+		t.Errorf("expected 409, got %d", rr.Code)
+	}
+	if tr.tasks[task.ID].Title == "Nope" {
+		t.Error("expected the patch to be discarded")
+	}
+}
+
+func TestPatchTask_Rejections(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	cases := []struct {
+		contentType, body string
+		status            int
+	}{
+		{"application/json", `{"title":"x"}`, http.StatusUnsupportedMediaType},
+		{mergePatchType, `{"title":""}`, http.StatusBadRequest},
+		{mergePatchType, `{"title":null}`, http.StatusBadRequest},
+		{mergePatchType, `{"status":"NOPE"}`, http.StatusBadRequest},
+		{mergePatchType, `{"status":null}`, http.StatusBadRequest},
+		{mergePatchType, `{"position":"first"}`, http.StatusBadRequest},
+		{mergePatchType, `{"version":7}`, http.StatusBadRequest},
+		{mergePatchType, `{"colour":"red"}`, http.StatusBadRequest},
+		{mergePatchType, `["not","an","object"]`, http.StatusUnprocessableEntity},
+		{mergePatchType, `{not json`, http.StatusBadRequest},
+		{jsonPatchType, `[{"op":"explode","path":"/title"}]`, http.StatusBadRequest},
+		{jsonPatchType, `[{"op":"remove","path":"/nothing"}]`, http.StatusUnprocessableEntity},
+		{jsonPatchType, `[{"op":"remove","path":"/id"}]`, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		if rr := patchTask(r, task, c.contentType, c.body); rr.Code != c.status {
+			t.Errorf("%s %s: expected %d, got %d: %s", c.contentType, c.body, c.status, rr.Code, rr.Body.String())
+		}
+	}
+	if stored := tr.tasks[task.ID]; stored.Version != 1 {
+		t.Errorf("expected the task untouched, got version %d", stored.Version)
+	}
+}
+
+func TestPatchTask_IfMatch(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+
+	req := httptest.NewRequest(http.MethodPatch, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), strings.NewReader(`{"title":"x"}`))
+	req.Header.Set("Content-Type", mergePatchType)
+	req.Header.Set("If-Match", `"v9"`)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	if rr.Code != http.StatusPreconditionFailed {
+		t.Errorf("expected 412, got %d", rr.Code)
+	}
+}
@@ -0,0 +1,50 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+// reapInterval is how often RunBoardReaper deletes expired boards.
+const reapInterval = 15 * time.Minute
+
+// ReapExpiredBoards deletes the boards that expired more than the configured
+// grace period ago, with their tasks, closes their event streams and returns
+// how many were deleted. It returns 0 and no error when another server is
+// already reaping.
+func (r *Router) ReapExpiredBoards(ctx context.Context) (int, error) {
+	ids, err := r.boardRepo.DeleteExpired(ctx, time.Now().Add(-r.expiryGrace))
+	if errors.Is(err, domain.ErrExpiryInProgress) {
+		log.Printf("Skipping expired board reaping: another server holds the lock")
+		return 0, nil
+	}
+	for _, id := range ids {
+		r.events.Close(id)
+	}
+	if len(ids) > 0 {
+		log.Printf("Reaped %d expired boards", len(ids))
+	}
+	return len(ids), err
+}
+
+// RunBoardReaper calls ReapExpiredBoards once at start-up and then every
+// reapInterval until ctx is done. Failures are logged and retried on the next
+// tick.
+func (r *Router) RunBoardReaper(ctx context.Context) {
+	ticker := time.NewTicker(reapInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := r.ReapExpiredBoards(ctx); err != nil {
+			log.Printf("Failed to reap expired boards: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestReapExpiredBoards(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	seedBoard(br, "ffffffffffffffff", true)
+
+	n, err := r.ReapExpiredBoards(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 board reaped, got %d", n)
+	}
+	if _, ok := br.boards["ffffffffffffffff"]; ok {
+		t.Error("expected the expired board deleted")
+	}
+	if _, ok := br.boards[testKey]; !ok {
+		t.Error("expected the active board kept")
+	}
+}
+
+func TestReapExpiredBoards_GracePeriod(t *testing.T) {
+	r, br, _ := newTestRouter()
+	r.expiryGrace = 24 * time.Hour
+	seedBoard(br, testKey, true) // expired an hour ago
+	old := seedBoard(br, "ffffffffffffffff", true)
+	old.ExpiresAt = time.Now().Add(-25 * time.Hour)
+
+	if n, _ := r.ReapExpiredBoards(context.Background()); n != 1 {
+		t.Errorf("expected 1 board reaped, got %d", n)
+	}
+	if _, ok := br.boards[testKey]; !ok {
+		t.Error("expected the board within its grace period kept")
+	}
+}
+
+func TestReapExpiredBoards_Locked(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, true)
+	br.expiryLocked = true
+
+	n, err := r.ReapExpiredBoards(context.Background())
+	if n != 0 || err != nil {
+		t.Errorf("expected the run skipped quietly, got %d, %v", n, err)
+	}
+	if _, ok := br.boards[testKey]; !ok {
+		t.Error("expected the board left for the server holding the lock")
+	}
+}
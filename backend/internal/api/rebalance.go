@@ -0,0 +1,49 @@
+package api
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+const (
+	// maxRankLength is the rank length past which a lane's ranks are
+	// respread. Repeated inserts at the same spot grow ranks by about one
+	// character per five inserts.
+	maxRankLength = 16
+	// rebalanceInterval is how often RunRankRebalancer looks for long ranks.
+	rebalanceInterval = 10 * time.Minute
+)
+
+// RebalanceRanks respreads the ranks of every lane holding a rank longer
+// than maxRankLength, and tells the affected boards' clients to reload since
+// the ranks they hold are stale. Task order does not change.
+func (r *Router) RebalanceRanks(ctx context.Context) error {
+	boardIDs, err := r.taskRepo.RebalanceRanks(ctx, maxRankLength)
+	for _, id := range boardIDs {
+		board, err := r.boardRepo.GetByID(ctx, id)
+		if err != nil {
+			log.Printf("Failed to fetch rebalanced board %s: %v", id, err)
+			continue
+		}
+		r.publish(board.ID, eventBoardUpdated, board)
+	}
+	return err
+}
+
+// RunRankRebalancer calls RebalanceRanks every rebalanceInterval until ctx is
+// done. Failures are logged and retried on the next tick.
+func (r *Router) RunRankRebalancer(ctx context.Context) {
+	ticker := time.NewTicker(rebalanceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.RebalanceRanks(ctx); err != nil {
+				log.Printf("Failed to rebalance task ranks: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package api
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRebalanceRanks(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	ids := createTasks(t, r, "TODO", "a", "b", "c")
+
+	// Give the lane the long ranks that repeated inserts at one spot produce.
+	long := strings.Repeat("0", maxRankLength)
+	for i, id := range ids {
+		tr.tasks[id].Rank = long + string(rune('1'+i))
+	}
+
+	sub := r.events.Subscribe(board.ID, 0)
+	defer sub.Cancel()
+	if err := r.RebalanceRanks(t.Context()); err != nil {
+		t.Fatalf("rebalance failed: %v", err)
+	}
+
+	if got := laneTitles(t, tr, board.ID, "TODO"); got != "a,b,c" {
+		t.Errorf("expected the order kept, got %s", got)
+	}
+	for _, id := range ids {
+		if task := tr.tasks[id]; len(task.Rank) > 1 || task.Version != 2 {
+			t.Errorf("%s: expected a short rank and a new version, got %q at v%d", task.Title, task.Rank, task.Version)
+		}
+	}
+	select {
+	case e := <-sub.Events:
+		if e.Type != eventBoardUpdated || !strings.Contains(string(e.Data), "Test Board") || strings.Contains(string(e.Data), testKey) {
+			t.Errorf("expected board.updated, got %+v", e)
+		}
+	case <-time.After(time.Second):
+		t.Error("expected a board.updated event")
+	}
+
+	// Short ranks are left alone.
+	if err := r.RebalanceRanks(t.Context()); err != nil {
+		t.Fatalf("rebalance failed: %v", err)
+	}
+	select {
+	case e := <-sub.Events:
+		t.Errorf("expected no event, got %+v", e)
+	default:
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"net/http"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/go-chi/chi/v5/middleware"
@@ -12,20 +13,48 @@ import (
 
 	"github.com/zeeshanejaz/kanbin/backend/internal/config"
 	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+	"github.com/zeeshanejaz/kanbin/backend/internal/events"
 )
 
 type Router struct {
 	*chi.Mux
-	boardRepo domain.BoardRepository
-	taskRepo  domain.TaskRepository
+	boardRepo       domain.BoardRepository
+	taskRepo        domain.TaskRepository
+	checklistRepo   domain.ChecklistRepository
+	dependencyRepo  domain.DependencyRepository
+	commentRepo     domain.CommentRepository
+	activityRepo    domain.ActivityRepository
+	idempotencyRepo domain.IdempotencyRepository
+	events          *events.Hub
+	allowedOrigins  []string
+	maxBoardTTL     time.Duration
+	expiryGrace     time.Duration
 }
 
 // NewRouter constructs the chi router with all middleware and routes registered.
-func NewRouter(boardRepo domain.BoardRepository, taskRepo domain.TaskRepository, cfg *config.Config) *Router {
+func NewRouter(
+	boardRepo domain.BoardRepository,
+	taskRepo domain.TaskRepository,
+	checklistRepo domain.ChecklistRepository,
+	dependencyRepo domain.DependencyRepository,
+	commentRepo domain.CommentRepository,
+	activityRepo domain.ActivityRepository,
+	idempotencyRepo domain.IdempotencyRepository,
+	cfg *config.Config,
+) *Router {
 	r := &Router{
-		Mux:       chi.NewRouter(),
-		boardRepo: boardRepo,
-		taskRepo:  taskRepo,
+		Mux:             chi.NewRouter(),
+		boardRepo:       boardRepo,
+		taskRepo:        taskRepo,
+		checklistRepo:   checklistRepo,
+		dependencyRepo:  dependencyRepo,
+		commentRepo:     commentRepo,
+		activityRepo:    activityRepo,
+		idempotencyRepo: idempotencyRepo,
+		events:          events.NewHub(),
+		allowedOrigins:  cfg.AllowedOrigins,
+		maxBoardTTL:     cfg.MaxBoardTTL,
+		expiryGrace:     cfg.BoardExpiryGrace,
 	}
 
 	// Middleware order: security headers → rate limit → CORS → logging/recovery
@@ -34,9 +63,9 @@ func NewRouter(boardRepo domain.BoardRepository, taskRepo domain.TaskRepository,
 
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins: cfg.AllowedOrigins,
-		AllowedMethods: []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token"},
-		ExposedHeaders: []string{"ETag"},
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Authorization", "Content-Type", "X-CSRF-Token", "If-Match", "If-None-Match", actorHeader, idempotencyHeader},
+		ExposedHeaders: []string{"ETag", "Idempotent-Replayed", accessHeader},
 	}))
 
 	r.Use(middleware.RequestID)
@@ -49,17 +78,56 @@ func NewRouter(boardRepo domain.BoardRepository, taskRepo domain.TaskRepository,
 	})
 
 	r.Route("/api", func(mux chi.Router) {
+		mux.Use(r.slideExpiry)
 		mux.Get("/health", r.handleHealth)
 
 		// Board routes — per-operation rate limits
-		mux.With(RateLimit("boardPost")).Post("/boards", r.handleCreateBoard)
+		mux.With(RateLimit("boardPost")).Post("/boards", r.idempotent(r.handleCreateBoard))
 		mux.With(RateLimit("boardGet")).Get("/boards/{key}", r.handleGetBoard)
+		mux.Put("/boards/{key}", r.handleUpdateBoard)
 		mux.Delete("/boards/{key}", r.handleDeleteBoard)
+		mux.Post("/boards/{key}/extend", r.handleExtendBoard)
+		mux.Get("/boards/{key}/activity", r.handleListActivity)
+		mux.Get("/boards/{key}/events", r.handleBoardEvents)
+		mux.Get("/boards/{key}/ws", r.handleBoardSocket)
+
+		// View key routes — read-only keys for sharing, managed with the board key
+		mux.Get("/boards/{key}/view-keys", r.handleListViewKeys)
+		mux.Post("/boards/{key}/view-keys", r.handleCreateViewKey)
+		mux.Delete("/boards/{key}/view-keys/{id}", r.handleRevokeViewKey)
+
+		// Lane routes — each board owns its ordered list of lanes
+		mux.Get("/boards/{key}/lanes", r.handleListLanes)
+		mux.Post("/boards/{key}/lanes", r.handleCreateLane)
+		mux.Put("/boards/{key}/lanes/{id}", r.handleUpdateLane)
+		mux.Delete("/boards/{key}/lanes/{id}", r.handleDeleteLane)
 
 		// Task routes — board key in path provides ownership proof
-		mux.Post("/boards/{key}/tasks", r.handleCreateTask)
+		mux.Get("/boards/{key}/tasks", r.handleSearchTasks)
+		mux.Post("/boards/{key}/batch", r.handleBatch)
+		mux.Post("/boards/{key}/tasks", r.idempotent(r.handleCreateTask))
+		mux.Get("/boards/{key}/tasks/{id}", r.handleGetTask)
 		mux.Put("/boards/{key}/tasks/{id}", r.handleUpdateTask)
+		mux.Patch("/boards/{key}/tasks/{id}", r.handlePatchTask)
 		mux.Delete("/boards/{key}/tasks/{id}", r.handleDeleteTask)
+		mux.Post("/boards/{key}/tasks/{id}/move", r.handleMoveTask)
+		mux.Post("/boards/{key}/tasks/{id}/restore", r.handleRestoreTask)
+		mux.Delete("/boards/{key}/tasks/{id}/purge", r.handlePurgeTask)
+
+		// Checklist routes — items are scoped to a task on the board
+		mux.Get("/boards/{key}/tasks/{id}/checklist", r.handleListChecklist)
+		mux.Post("/boards/{key}/tasks/{id}/checklist", r.handleCreateChecklistItem)
+		mux.Put("/boards/{key}/tasks/{id}/checklist/{itemID}", r.handleUpdateChecklistItem)
+		mux.Delete("/boards/{key}/tasks/{id}/checklist/{itemID}", r.handleDeleteChecklistItem)
+
+		// Dependency routes — {id} is blocked by {blockerID}
+		mux.Post("/boards/{key}/tasks/{id}/dependencies", r.handleAddDependency)
+		mux.Delete("/boards/{key}/tasks/{id}/dependencies/{blockerID}", r.handleRemoveDependency)
+
+		// Comment routes — append-only thread per task
+		mux.Get("/boards/{key}/tasks/{id}/comments", r.handleListComments)
+		mux.Post("/boards/{key}/tasks/{id}/comments", r.handleCreateComment)
+		mux.Delete("/boards/{key}/tasks/{id}/comments/{commentID}", r.handleDeleteComment)
 	})
 
 	return r
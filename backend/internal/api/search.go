@@ -0,0 +1,62 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	maxSearchQueryLength   = 200
+	defaultSearchResults   = 20
+	maxSearchResultsPerReq = 100
+)
+
+// SearchResponse is the body of GET /boards/{key}/tasks?q=.
+type SearchResponse struct {
+	Query   string                     `json:"query"`
+	Results []*domain.TaskSearchResult `json:"results"`
+}
+
+// handleSearchTasks runs a full-text search over the titles and descriptions
+// of the board's unarchived tasks. The query accepts web-search syntax:
+// quoted phrases, "or", and a leading "-" to exclude a word.
+func (r *Router) handleSearchTasks(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	q := strings.TrimSpace(req.URL.Query().Get("q"))
+	if q == "" {
+		respondError(w, http.StatusBadRequest, "Query parameter q is required")
+		return
+	}
+	if len(q) > maxSearchQueryLength {
+		respondError(w, http.StatusBadRequest, fmt.Sprintf("q must be %d characters or fewer", maxSearchQueryLength))
+		return
+	}
+
+	limit := defaultSearchResults
+	if v := req.URL.Query().Get("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxSearchResultsPerReq {
+			respondError(w, http.StatusBadRequest, fmt.Sprintf("limit must be between 1 and %d", maxSearchResultsPerReq))
+			return
+		}
+		limit = n
+	}
+
+	results, err := r.taskRepo.Search(req.Context(), board.ID, q, limit)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to search tasks")
+		return
+	}
+	if results == nil {
+		results = []*domain.TaskSearchResult{}
+	}
+	respondJSON(w, http.StatusOK, SearchResponse{Query: q, Results: results})
+}
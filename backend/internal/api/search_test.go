@@ -0,0 +1,104 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func searchTasks(t *testing.T, r *Router, q string) (int, SearchResponse) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/boards/"+testKey+"/tasks?q="+url.QueryEscape(q), nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	var resp SearchResponse
+	if rr.Code == http.StatusOK {
+		if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil {
+			t.Fatalf("failed to decode response: %v", err)
+		}
+	}
+	return rr.Code, resp
+}
+
+func TestSearchTasks_RanksAndSkipsArchived(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+
+	inDesc := seedTask(tr, board.ID)
+	inDesc.Title = "Tidy config"
+	inDesc.Description = "The login flow reads this"
+	inTitle := seedTask(tr, board.ID)
+	inTitle.Title = "Fix login redirect"
+	archived := seedTask(tr, board.ID)
+	archived.Title = "Old login page"
+	archived.ArchivedAt = &archived.CreatedAt
+	seedTask(tr, board.ID)
+
+	code, resp := searchTasks(t, r, "login")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if resp.Query != "login" || len(resp.Results) != 2 {
+		t.Fatalf("expected 2 results for login, got %d", len(resp.Results))
+	}
+	if resp.Results[0].ID != inTitle.ID {
+		t.Errorf("expected the title match to rank first")
+	}
+}
+
+func TestSearchTasks_NoMatches_ReturnsEmptyArray(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	seedTask(tr, board.ID)
+
+	code, resp := searchTasks(t, r, "nothing-matches")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if resp.Results == nil || len(resp.Results) != 0 {
+		t.Errorf("expected an empty results array, got %v", resp.Results)
+	}
+}
+
+func TestSearchTasks_MissingQuery_Returns400(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	if code, _ := searchTasks(t, r, "  "); code != http.StatusBadRequest {
+		t.Errorf("expected 400, got %d", code)
+	}
+}
+
+func TestSearchTasks_ExpiredBoard_Returns410(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, true)
+	if code, _ := searchTasks(t, r, "login"); code != http.StatusGone {
+		t.Errorf("expected 410, got %d", code)
+	}
+}
+
+func TestCreateTask_StripsControlCharacters(t *testing.T) {
+	r, br, tr := newTestRouter()
+	seedBoard(br, testKey, false)
+
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks",
+		strings.NewReader(`{"title":"a\u0002b\u0003","description":"line\u0000\nnext\tcol"}`)))
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	for _, task := range tr.tasks {
+		if task.Title != "ab" || task.Description != "line\nnext\tcol" {
+			t.Errorf("expected control characters stripped, got %q / %q", task.Title, task.Description)
+		}
+	}
+
+	rr = httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/boards/"+testKey+"/tasks",
+		strings.NewReader(`{"title":"\u0002\u0003"}`)))
+	if rr.Code != http.StatusBadRequest {
+		t.Errorf("expected 400 for a title of only control characters, got %d", rr.Code)
+	}
+}
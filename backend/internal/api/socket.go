@@ -0,0 +1,287 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+	"github.com/zeeshanejaz/kanbin/backend/internal/events"
+)
+
+const (
+	// maxSocketMessageSize bounds a single client message.
+	maxSocketMessageSize = 64 << 10
+	// socketPingInterval is how often the server pings; a client that has
+	// not answered within socketPongWait is disconnected.
+	socketPingInterval = 25 * time.Second
+	socketPongWait     = 60 * time.Second
+	socketWriteWait    = 10 * time.Second
+	// socketReplyBuffer is how many acknowledgements may queue while the
+	// connection is busy writing events.
+	socketReplyBuffer = 16
+)
+
+// Reply types sent on GET /boards/{key}/ws.
+const (
+	socketAck   = "ack"
+	socketError = "error"
+	socketEvent = "event"
+)
+
+// SocketReq is a mutation sent by a socket client. ID is chosen by the client
+// and echoed in the reply. Task holds a CreateTaskReq for create, an
+// UpdateTaskReq for update and a MoveTaskReq for move, as in a batch.
+// Version, when set on an update or move, plays the part of If-Match.
+type SocketReq struct {
+	ID      string          `json:"id"`
+	Op      string          `json:"op"`
+	TaskID  uuid.UUID       `json:"task_id,omitempty"`
+	Version int             `json:"version,omitempty"`
+	Task    json.RawMessage `json:"task"`
+}
+
+// SocketReply is sent by the server. An ack carries the stored task, an error
+// carries the HTTP status the same request would have received, and an event
+// carries a board change exactly as the event stream would. An error for a
+// stale version also carries the task as currently stored.
+type SocketReply struct {
+	Type    string          `json:"type"`
+	ID      string          `json:"id,omitempty"`
+	Task    *domain.Task    `json:"task,omitempty"`
+	Status  int             `json:"status,omitempty"`
+	Error   string          `json:"error,omitempty"`
+	Event   string          `json:"event,omitempty"`
+	EventID uint64          `json:"event_id,omitempty"`
+	Data    json.RawMessage `json:"data,omitempty"`
+}
+
+func eventReply(id uint64, eventType string, data json.RawMessage) SocketReply {
+	return SocketReply{Type: socketEvent, Event: eventType, EventID: id, Data: data}
+}
+
+// checkOrigin accepts clients without an Origin header, such as CLIs and
+// TUIs, and browsers on an origin allowed by CORS.
+func (r *Router) checkOrigin(req *http.Request) bool {
+	origin := req.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+	for _, allowed := range r.allowedOrigins {
+		if allowed == "*" || allowed == origin {
+			return true
+		}
+	}
+	return false
+}
+
+// handleBoardSocket upgrades to a WebSocket that carries the board's events
+// and accepts task mutations. Browsers cannot set Last-Event-ID on a socket,
+// so resuming uses the last_event_id query parameter instead.
+func (r *Router) handleBoardSocket(w http.ResponseWriter, req *http.Request) {
+	// View keys may follow events; handleSocketReq refuses their mutations.
+	board, access := r.boardAccessFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	var lastID uint64
+	if v := req.URL.Query().Get("last_event_id"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid last_event_id")
+			return
+		}
+		lastID = n
+	}
+
+	upgrader := websocket.Upgrader{CheckOrigin: r.checkOrigin}
+	conn, err := upgrader.Upgrade(w, req, nil)
+	if err != nil {
+		return // the upgrader has already responded
+	}
+	defer conn.Close()
+
+	sub := r.events.Subscribe(board.ID, lastID)
+	defer sub.Cancel()
+
+	ctx, cancel := context.WithCancel(req.Context())
+	defer cancel()
+	replies := make(chan SocketReply, socketReplyBuffer)
+	go r.writeSocket(ctx, cancel, conn, sub, replies)
+
+	conn.SetReadLimit(maxSocketMessageSize)
+	conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(socketPongWait))
+	})
+
+	// Messages are handled one at a time, so a client's mutations apply in
+	// the order it sent them.
+	for {
+		var msg SocketReq
+		if err := conn.ReadJSON(&msg); err != nil {
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) {
+				r.sendReply(ctx, replies, SocketReply{Type: socketError, Status: http.StatusBadRequest, Error: "Invalid message"})
+				continue
+			}
+			return
+		}
+		r.sendReply(ctx, replies, r.handleSocketReq(req, board.ID, access, msg))
+	}
+}
+
+func (r *Router) sendReply(ctx context.Context, replies chan<- SocketReply, reply SocketReply) {
+	select {
+	case replies <- reply:
+	case <-ctx.Done():
+	}
+}
+
+// writeSocket is the connection's only writer. It sends the replay, then
+// interleaves live events, replies and pings until ctx ends or the
+// subscription is closed, and cancels ctx when it stops.
+func (r *Router) writeSocket(ctx context.Context, cancel context.CancelFunc, conn *websocket.Conn, sub *events.Subscription, replies <-chan SocketReply) {
+	defer cancel()
+	write := func(reply SocketReply) bool {
+		conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+		return conn.WriteJSON(reply) == nil
+	}
+
+	status := eventReady
+	if sub.Complete {
+		for _, e := range sub.Replay {
+			if !write(eventReply(e.ID, e.Type, e.Data)) {
+				return
+			}
+		}
+	} else {
+		status = eventReset
+	}
+	if !write(eventReply(sub.LastID, status, json.RawMessage("{}"))) {
+		return
+	}
+
+	ping := time.NewTicker(socketPingInterval)
+	defer ping.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				// Board deleted, or this client fell too far behind; it can
+				// reconnect with last_event_id to catch up.
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseGoingAway, ""),
+					time.Now().Add(socketWriteWait))
+				conn.Close()
+				return
+			}
+			if !write(eventReply(e.ID, e.Type, e.Data)) {
+				return
+			}
+		case reply := <-replies:
+			if !write(reply) {
+				return
+			}
+		case <-ping.C:
+			if err := conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(socketWriteWait)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// handleSocketReq applies one mutation with the same validation, limits and
+// side effects as the equivalent HTTP request. The board is looked up again
+// for every message because it may have expired or been deleted since the
+// socket was opened.
+func (r *Router) handleSocketReq(req *http.Request, boardID uuid.UUID, access domain.Access, msg SocketReq) SocketReply {
+	fail := func(status int, message string) SocketReply {
+		return SocketReply{Type: socketError, ID: msg.ID, Status: status, Error: message}
+	}
+
+	if access != domain.AccessEdit {
+		return fail(http.StatusForbidden, readOnlyMessage)
+	}
+
+	if !getVisitor(extractIP(req)).global.Allow() {
+		return fail(http.StatusTooManyRequests, "Rate limit exceeded — try again later")
+	}
+
+	board, err := r.boardRepo.GetByID(req.Context(), boardID)
+	if err != nil {
+		return fail(http.StatusNotFound, "Board not found")
+	}
+	if time.Now().After(board.ExpiresAt) {
+		return fail(http.StatusGone, "Board has expired")
+	}
+
+	if msg.Op == batchCreate {
+		var reqBody CreateTaskReq
+		if err := json.Unmarshal(msg.Task, &reqBody); err != nil {
+			return fail(http.StatusBadRequest, "Invalid task payload")
+		}
+		task, status, message := r.createTask(req, board, reqBody)
+		if message != "" {
+			return fail(status, message)
+		}
+		r.slideBoardExpiry(req, chi.URLParam(req, "key"))
+		return SocketReply{Type: socketAck, ID: msg.ID, Task: task}
+	}
+
+	var reqBody UpdateTaskReq
+	var move MoveTaskReq
+	switch msg.Op {
+	case batchUpdate:
+		if err := json.Unmarshal(msg.Task, &reqBody); err != nil {
+			return fail(http.StatusBadRequest, "Invalid task payload")
+		}
+	case batchMove:
+		if err := json.Unmarshal(msg.Task, &move); err != nil {
+			return fail(http.StatusBadRequest, "Invalid task payload")
+		}
+	default:
+		return fail(http.StatusBadRequest, "op must be one of create, update, move")
+	}
+
+	task, err := r.taskRepo.GetByID(req.Context(), msg.TaskID)
+	if err != nil || task.BoardID != board.ID {
+		return fail(http.StatusNotFound, "Task not found on this board")
+	}
+	conflict := func(current *domain.Task) SocketReply {
+		reply := fail(http.StatusPreconditionFailed, taskChangedMessage)
+		reply.Task = current
+		return reply
+	}
+	if msg.Version != 0 && msg.Version != task.Version {
+		return conflict(task)
+	}
+	var neighbour *domain.Neighbour
+	if msg.Op == batchMove {
+		var message string
+		if reqBody, neighbour, message = r.resolveMove(req.Context(), task, move); message != "" {
+			return fail(http.StatusBadRequest, message)
+		}
+	}
+	if status, message := r.updateTask(req, board, task, reqBody, neighbour); message != "" {
+		// The task can still change between the check above and the write.
+		if msg.Version != 0 && message == taskChangedMessage {
+			current, _ := r.taskRepo.GetByID(req.Context(), task.ID)
+			return conflict(current)
+		}
+		return fail(status, message)
+	}
+	r.slideBoardExpiry(req, chi.URLParam(req, "key"))
+	return SocketReply{Type: socketAck, ID: msg.ID, Task: task}
+}
@@ -0,0 +1,171 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+func dialBoardSocket(t *testing.T, srv *httptest.Server, header http.Header) *websocket.Conn {
+	t.Helper()
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/boards/" + testKey + "/ws"
+	conn, resp, err := websocket.DefaultDialer.Dial(url, header)
+	if err != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		t.Fatalf("dial failed (status %d): %v", status, err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	if reply := readReply(t, conn); reply.Event != eventReady {
+		t.Fatalf("expected ready, got %+v", reply)
+	}
+	return conn
+}
+
+func readReply(t *testing.T, conn *websocket.Conn) SocketReply {
+	t.Helper()
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	var reply SocketReply
+	if err := conn.ReadJSON(&reply); err != nil {
+		t.Fatalf("read failed: %v", err)
+	}
+	return reply
+}
+
+// readUntil skips replies until one of the given type arrives, since events
+// and acknowledgements may interleave in either order.
+func readUntil(t *testing.T, conn *websocket.Conn, replyType string) SocketReply {
+	t.Helper()
+	for {
+		if reply := readReply(t, conn); reply.Type == replyType {
+			return reply
+		}
+	}
+}
+
+func TestBoardSocket_CreateAndMove(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	conn := dialBoardSocket(t, srv, nil)
+
+	conn.WriteJSON(map[string]interface{}{"id": "c1", "op": "create", "task": map[string]string{"title": "via socket"}})
+	ack := readUntil(t, conn, socketAck)
+	if ack.ID != "c1" || ack.Task == nil || ack.Task.Title != "via socket" {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+
+	conn.WriteJSON(map[string]interface{}{"id": "c2", "op": "move", "task_id": ack.Task.ID, "task": map[string]string{"status": "DONE"}})
+	ack = readUntil(t, conn, socketAck)
+	if ack.ID != "c2" || ack.Task.Status != "DONE" {
+		t.Fatalf("unexpected ack: %+v", ack)
+	}
+	if stored, _ := r.taskRepo.GetByID(t.Context(), ack.Task.ID); stored.Status != "DONE" {
+		t.Errorf("expected the move to be stored, got %s", stored.Status)
+	}
+}
+
+func TestBoardSocket_ReceivesOtherClientsChanges(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	conn := dialBoardSocket(t, srv, nil)
+
+	resp, err := http.Post(srv.URL+"/api/boards/"+testKey+"/tasks", "application/json", strings.NewReader(`{"title":"over http"}`))
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	resp.Body.Close()
+
+	reply := readReply(t, conn)
+	if reply.Type != socketEvent || reply.Event != eventTaskCreated || !strings.Contains(string(reply.Data), "over http") {
+		t.Errorf("expected task.created event, got %+v", reply)
+	}
+}
+
+func TestBoardSocket_ErrorsCarryStatusAndID(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	conn := dialBoardSocket(t, srv, nil)
+
+	cases := []struct {
+		msg    map[string]interface{}
+		status int
+	}{
+		{map[string]interface{}{"id": "a", "op": "move", "task_id": task.ID, "task": map[string]string{"status": "NOPE"}}, http.StatusBadRequest},
+		{map[string]interface{}{"id": "b", "op": "move", "task_id": task.ID, "task": map[string]string{}}, http.StatusBadRequest},
+		{map[string]interface{}{"id": "c", "op": "update", "task_id": "00000000-0000-0000-0000-000000000001", "task": map[string]string{"title": "x"}}, http.StatusNotFound},
+		{map[string]interface{}{"id": "d", "op": "create", "task": map[string]string{"title": ""}}, http.StatusBadRequest},
+		{map[string]interface{}{"id": "e", "op": "explode"}, http.StatusBadRequest},
+	}
+	for _, c := range cases {
+		conn.WriteJSON(c.msg)
+		reply := readUntil(t, conn, socketError)
+		if reply.ID != c.msg["id"] || reply.Status != c.status || reply.Error == "" {
+			t.Errorf("message %v: unexpected reply %+v", c.msg["id"], reply)
+		}
+	}
+
+	// The connection survives a malformed message.
+	conn.WriteMessage(websocket.TextMessage, []byte("{not json"))
+	if reply := readUntil(t, conn, socketError); reply.Status != http.StatusBadRequest {
+		t.Errorf("expected 400 for malformed message, got %+v", reply)
+	}
+}
+
+func TestBoardSocket_RejectsForeignOrigin(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	url := "ws" + strings.TrimPrefix(srv.URL, "http") + "/api/boards/" + testKey + "/ws"
+	_, resp, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"http://evil.example"}})
+	if err == nil {
+		t.Fatal("expected the handshake to fail")
+	}
+	if resp == nil || resp.StatusCode != http.StatusForbidden {
+		t.Errorf("expected 403, got %v", resp)
+	}
+
+	dialBoardSocket(t, srv, http.Header{"Origin": {"http://localhost:5173"}})
+}
+
+func TestBoardSocket_StaleVersionConflicts(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+	conn := dialBoardSocket(t, srv, nil)
+
+	conn.WriteJSON(map[string]interface{}{"id": "u1", "op": "update", "task_id": task.ID, "version": 1, "task": map[string]string{"title": "first"}})
+	if ack := readUntil(t, conn, socketAck); ack.Task.Version != 2 {
+		t.Fatalf("expected version 2, got %+v", ack.Task)
+	}
+
+	// A second writer still holding version 1 is refused and shown the current task.
+	conn.WriteJSON(map[string]interface{}{"id": "u2", "op": "move", "task_id": task.ID, "version": 1, "task": map[string]string{"status": "DONE"}})
+	reply := readUntil(t, conn, socketError)
+	if reply.ID != "u2" || reply.Status != http.StatusPreconditionFailed {
+		t.Fatalf("expected a 412 error, got %+v", reply)
+	}
+	if reply.Task == nil || reply.Task.Title != "first" || reply.Task.Version != 2 {
+		t.Errorf("expected the current task in the reply, got %+v", reply.Task)
+	}
+	if tr.tasks[task.ID].Status != "TODO" {
+		t.Error("expected the stale move to be refused")
+	}
+}
@@ -0,0 +1,121 @@
+package api
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event types sent on GET /boards/{key}/events.
+const (
+	eventTaskCreated  = "task.created"
+	eventTaskUpdated  = "task.updated"
+	eventTaskDeleted  = "task.deleted"
+	eventBoardUpdated = "board.updated"
+	eventBoardDeleted = "board.deleted"
+
+	// eventReady is sent once a stream is live; eventReset replaces it when
+	// the requested Last-Event-ID can no longer be replayed.
+	eventReady = "ready"
+	eventReset = "reset"
+)
+
+// streamKeepAlive is how often an idle stream sends a comment line so that
+// proxies do not close it.
+const streamKeepAlive = 25 * time.Second
+
+// TaskDeletedEvent is the payload of task.deleted. Archived is false when the
+// task was purged.
+type TaskDeletedEvent struct {
+	ID       uuid.UUID `json:"id"`
+	Archived bool      `json:"archived"`
+}
+
+// BoardDeletedEvent is the payload of board.deleted. It is empty: the stream
+// already identifies the board, and its key is never sent after creation.
+type BoardDeletedEvent struct{}
+
+// publish notifies the board's event stream. Like recordActivity, a failure
+// is logged rather than failing a request whose change is already stored.
+func (r *Router) publish(boardID uuid.UUID, eventType string, data interface{}) {
+	if err := r.events.Publish(boardID, eventType, data); err != nil {
+		log.Printf("Failed to publish %s event for board %s: %v", eventType, boardID, err)
+	}
+}
+
+func writeEvent(w http.ResponseWriter, id uint64, eventType string, data []byte) {
+	fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, eventType, data)
+}
+
+// handleBoardEvents streams the board's changes as Server-Sent Events. A
+// client reconnecting with Last-Event-ID first receives the events it missed,
+// or a reset event if they are no longer available and it should reload the
+// board.
+func (r *Router) handleBoardEvents(w http.ResponseWriter, req *http.Request) {
+	board := r.activeBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		respondError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	var lastID uint64
+	if v := req.Header.Get("Last-Event-ID"); v != "" {
+		n, err := strconv.ParseUint(v, 10, 64)
+		if err != nil {
+			respondError(w, http.StatusBadRequest, "Invalid Last-Event-ID")
+			return
+		}
+		lastID = n
+	}
+
+	sub := r.events.Subscribe(board.ID, lastID)
+	defer sub.Cancel()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no") // disable proxy buffering
+	w.WriteHeader(http.StatusOK)
+
+	status := eventReady
+	if sub.Complete {
+		for _, e := range sub.Replay {
+			writeEvent(w, e.ID, e.Type, e.Data)
+		}
+	} else {
+		status = eventReset
+	}
+	// Carrying the current ID lets a client that has seen no events yet
+	// resume without gaps.
+	writeEvent(w, sub.LastID, status, []byte("{}"))
+	flusher.Flush()
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-req.Context().Done():
+			return
+		case e, ok := <-sub.Events:
+			if !ok {
+				// Board deleted, or this client fell too far behind; it can
+				// reconnect with Last-Event-ID to catch up.
+				return
+			}
+			writeEvent(w, e.ID, e.Type, e.Data)
+			flusher.Flush()
+		case <-keepAlive.C:
+			fmt.Fprint(w, ": keep-alive\n\n")
+			flusher.Flush()
+		}
+	}
+}
@@ -0,0 +1,155 @@
+package api
+
+import (
+	"bufio"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type sseEvent struct {
+	id, event, data string
+}
+
+// openStream connects to the board's event stream and returns a function
+// reading the next event.
+func openStream(t *testing.T, srv *httptest.Server, lastEventID string) (func() sseEvent, func()) {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, srv.URL+"/api/boards/"+testKey+"/events", nil)
+	if lastEventID != "" {
+		req.Header.Set("Last-Event-ID", lastEventID)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("failed to open stream: %v", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("expected 200, got %d", resp.StatusCode)
+	}
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Fatalf("expected text/event-stream, got %q", ct)
+	}
+
+	lines := make(chan string)
+	go func() {
+		defer close(lines)
+		scanner := bufio.NewScanner(resp.Body)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+	}()
+	next := func() sseEvent {
+		t.Helper()
+		var e sseEvent
+		for {
+			select {
+			case line, ok := <-lines:
+				if !ok {
+					return e
+				}
+				switch {
+				case line == "" && e.event != "":
+					return e
+				case strings.HasPrefix(line, "id: "):
+					e.id = strings.TrimPrefix(line, "id: ")
+				case strings.HasPrefix(line, "event: "):
+					e.event = strings.TrimPrefix(line, "event: ")
+				case strings.HasPrefix(line, "data: "):
+					e.data = strings.TrimPrefix(line, "data: ")
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("timed out waiting for an event")
+			}
+		}
+	}
+	return next, func() { resp.Body.Close() }
+}
+
+func TestBoardEvents_StreamsTaskChanges(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	next, done := openStream(t, srv, "")
+	defer done()
+	if e := next(); e.event != eventReady {
+		t.Fatalf("expected ready, got %q", e.event)
+	}
+
+	resp, err := http.Post(srv.URL+"/api/boards/"+testKey+"/tasks", "application/json", strings.NewReader(`{"title":"streamed"}`))
+	if err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	resp.Body.Close()
+
+	e := next()
+	if e.event != eventTaskCreated || !strings.Contains(e.data, `"title":"streamed"`) {
+		t.Errorf("expected task.created with the task, got %q %s", e.event, e.data)
+	}
+}
+
+func TestBoardEvents_ResumeWithLastEventID(t *testing.T) {
+	r, br, tr := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	task := seedTask(tr, board.ID)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	next, done := openStream(t, srv, "")
+	ready := next()
+	done()
+
+	// Changes made while disconnected are replayed on reconnect.
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(http.MethodDelete, "/api/boards/"+testKey+"/tasks/"+task.ID.String(), nil))
+
+	next, done = openStream(t, srv, ready.id)
+	defer done()
+	if e := next(); e.event != eventTaskDeleted || !strings.Contains(e.data, task.ID.String()) {
+		t.Errorf("expected replayed task.deleted, got %q %s", e.event, e.data)
+	}
+	if e := next(); e.event != eventReady {
+		t.Errorf("expected ready after the replay, got %q", e.event)
+	}
+}
+
+func TestBoardEvents_UnknownLastEventID_SendsReset(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	next, done := openStream(t, srv, "1")
+	defer done()
+	if e := next(); e.event != eventReset {
+		t.Errorf("expected reset, got %q", e.event)
+	}
+}
+
+func TestBoardEvents_BoardDeletedEndsStream(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	srv := httptest.NewServer(r)
+	defer srv.Close()
+
+	next, done := openStream(t, srv, "")
+	defer done()
+	next()
+
+	req, _ := http.NewRequest(http.MethodDelete, srv.URL+"/api/boards/"+testKey, nil)
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("delete failed: %v", err)
+	}
+	resp.Body.Close()
+
+	if e := next(); e.event != eventBoardDeleted {
+		t.Errorf("expected board.deleted, got %q", e.event)
+	}
+	if e := next(); e.event != "" {
+		t.Errorf("expected the stream to end, got %q", e.event)
+	}
+}
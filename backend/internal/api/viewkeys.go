@@ -0,0 +1,133 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+const (
+	// accessHeader tells the caller whether its board key can edit the
+	// board or only view it.
+	accessHeader = "X-Kanbin-Access"
+
+	maxViewKeysPerBoard = 20
+	// viewKeyPrefixLength is how much of a view key is stored in plaintext
+	// and listed, enough to tell a board's view keys apart.
+	viewKeyPrefixLength = 6
+
+	readOnlyMessage = "This key can only view the board — use the board key to change it"
+)
+
+// isRead reports whether a request only reads, and so is allowed with a view
+// key.
+func isRead(req *http.Request) bool {
+	return req.Method == http.MethodGet || req.Method == http.MethodHead
+}
+
+// allowAccess writes a 403 and returns false if the request would change a
+// board through a view key.
+func allowAccess(w http.ResponseWriter, req *http.Request, access domain.Access) bool {
+	if access == domain.AccessView && !isRead(req) {
+		respondError(w, http.StatusForbidden, readOnlyMessage)
+		return false
+	}
+	return true
+}
+
+// editBoardFromPath is activeBoardFromPath for endpoints that need the
+// board's own key even to read, such as those managing its view keys.
+func (r *Router) editBoardFromPath(w http.ResponseWriter, req *http.Request) *domain.Board {
+	board, access := r.boardAccessFromPath(w, req)
+	if board == nil {
+		return nil
+	}
+	if access != domain.AccessEdit {
+		respondError(w, http.StatusForbidden, readOnlyMessage)
+		return nil
+	}
+	return board
+}
+
+func (r *Router) handleListViewKeys(w http.ResponseWriter, req *http.Request) {
+	board := r.editBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	viewKeys, err := r.boardRepo.ListViewKeys(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch view keys")
+		return
+	}
+	if viewKeys == nil {
+		viewKeys = []*domain.ViewKey{}
+	}
+	respondJSON(w, http.StatusOK, viewKeys)
+}
+
+func (r *Router) handleCreateViewKey(w http.ResponseWriter, req *http.Request) {
+	board := r.editBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	existing, err := r.boardRepo.ListViewKeys(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch view keys")
+		return
+	}
+	if len(existing) >= maxViewKeysPerBoard {
+		respondError(w, http.StatusUnprocessableEntity, fmt.Sprintf("View key limit reached (%d)", maxViewKeysPerBoard))
+		return
+	}
+
+	key := generateBoardKey()
+	viewKey := &domain.ViewKey{
+		ID:        uuid.New(),
+		BoardID:   board.ID,
+		Key:       key,
+		Prefix:    key[:viewKeyPrefixLength],
+		CreatedAt: time.Now(),
+	}
+	if err := r.boardRepo.CreateViewKey(req.Context(), viewKey); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to create view key")
+		return
+	}
+	respondJSON(w, http.StatusCreated, viewKey)
+}
+
+func (r *Router) handleRevokeViewKey(w http.ResponseWriter, req *http.Request) {
+	board := r.editBoardFromPath(w, req)
+	if board == nil {
+		return
+	}
+
+	id, err := uuid.Parse(chi.URLParam(req, "id"))
+	if err != nil {
+		respondError(w, http.StatusBadRequest, "Invalid view key ID format")
+		return
+	}
+
+	viewKeys, err := r.boardRepo.ListViewKeys(req.Context(), board.ID)
+	if err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to fetch view keys")
+		return
+	}
+	if !slices.ContainsFunc(viewKeys, func(vk *domain.ViewKey) bool { return vk.ID == id }) {
+		respondError(w, http.StatusNotFound, "View key not found")
+		return
+	}
+
+	if err := r.boardRepo.DeleteViewKey(req.Context(), id); err != nil {
+		respondError(w, http.StatusInternalServerError, "Failed to revoke view key")
+		return
+	}
+	respondJSON(w, http.StatusOK, map[string]string{"message": "revoked"})
+}
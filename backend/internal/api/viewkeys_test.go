@@ -0,0 +1,146 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+func doRequest(r http.Handler, method, path, body string) *httptest.ResponseRecorder {
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, httptest.NewRequest(method, "/api/boards/"+path, strings.NewReader(body)))
+	return rr
+}
+
+func createViewKey(t *testing.T, r http.Handler) domain.ViewKey {
+	t.Helper()
+	rr := doRequest(r, http.MethodPost, testKey+"/view-keys", "")
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var vk domain.ViewKey
+	json.NewDecoder(rr.Body).Decode(&vk)
+	if !boardKeyRe.MatchString(vk.Key) || vk.Key == testKey {
+		t.Fatalf("expected a new board-style key, got %q", vk.Key)
+	}
+	return vk
+}
+
+func TestViewKey_ReadsBoard(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	ids := createTasks(t, r, "TODO", "a")
+	vk := createViewKey(t, r)
+
+	rr := doRequest(r, http.MethodGet, vk.Key, "")
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if got := rr.Header().Get(accessHeader); got != "view" {
+		t.Errorf("expected %s: view, got %q", accessHeader, got)
+	}
+	if strings.Contains(rr.Body.String(), testKey) {
+		t.Error("expected the board key hidden from a view key")
+	}
+	var resp struct {
+		Access domain.Access  `json:"access"`
+		Tasks  []*domain.Task `json:"tasks"`
+	}
+	json.NewDecoder(rr.Body).Decode(&resp)
+	if resp.Access != domain.AccessView || len(resp.Tasks) != 1 {
+		t.Errorf("expected view access and the task, got %q with %d tasks", resp.Access, len(resp.Tasks))
+	}
+
+	if rr := doRequest(r, http.MethodGet, vk.Key+"/tasks/"+ids[0].String(), ""); rr.Code != http.StatusOK {
+		t.Errorf("expected a task read with the view key to succeed, got %d", rr.Code)
+	}
+	if rr := doRequest(r, http.MethodGet, testKey, ""); rr.Header().Get(accessHeader) != "edit" {
+		t.Errorf("expected edit access with the board key, got %q", rr.Header().Get(accessHeader))
+	}
+}
+
+func TestViewKey_RejectsChanges(t *testing.T) {
+	r, br, _ := newTestRouter()
+	board := seedBoard(br, testKey, false)
+	ids := createTasks(t, r, "TODO", "a")
+	vk := createViewKey(t, r)
+
+	cases := []struct {
+		method, path, body string
+	}{
+		{http.MethodPost, vk.Key + "/tasks", `{"title":"b"}`},
+		{http.MethodPut, vk.Key + "/tasks/" + ids[0].String(), `{"title":"renamed"}`},
+		{http.MethodPost, vk.Key + "/tasks/" + ids[0].String() + "/move", `{"status":"DONE"}`},
+		{http.MethodDelete, vk.Key + "/tasks/" + ids[0].String(), ""},
+		{http.MethodPut, vk.Key, `{"title":"renamed"}`},
+		{http.MethodPost, vk.Key + "/extend", ""},
+		{http.MethodDelete, vk.Key, ""},
+		{http.MethodPost, vk.Key + "/view-keys", ""},
+		{http.MethodGet, vk.Key + "/view-keys", ""},
+	}
+	for _, c := range cases {
+		if rr := doRequest(r, c.method, c.path, c.body); rr.Code != http.StatusForbidden {
+			t.Errorf("%s %s: expected 403, got %d", c.method, c.path, rr.Code)
+		}
+	}
+	if _, ok := br.boards[testKey]; !ok || board.Title != "Test Board" {
+		t.Error("expected the board unchanged")
+	}
+	if task := r.taskRepo.(*mockTaskRepo).tasks[ids[0]]; task.Title != "a" || task.ArchivedAt != nil {
+		t.Error("expected the task unchanged")
+	}
+
+	reply := r.handleSocketReq(httptest.NewRequest(http.MethodGet, "/", nil), board.ID, domain.AccessView,
+		SocketReq{ID: "m1", Op: batchCreate, Task: json.RawMessage(`{"title":"b"}`)})
+	if reply.Type != socketError || reply.Status != http.StatusForbidden {
+		t.Errorf("expected a 403 socket error, got %+v", reply)
+	}
+}
+
+func TestViewKey_ListAndRevoke(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	first := createViewKey(t, r)
+	second := createViewKey(t, r)
+
+	rr := doRequest(r, http.MethodGet, testKey+"/view-keys", "")
+	if strings.Contains(rr.Body.String(), first.Key) || strings.Contains(rr.Body.String(), second.Key) {
+		t.Error("expected view keys only in the response that creates them")
+	}
+	var listed []domain.ViewKey
+	json.NewDecoder(rr.Body).Decode(&listed)
+	if len(listed) != 2 || listed[0].ID != first.ID {
+		t.Fatalf("expected both keys oldest first, got %+v", listed)
+	}
+	if listed[0].Prefix != first.Key[:viewKeyPrefixLength] || listed[1].Prefix != second.Key[:viewKeyPrefixLength] {
+		t.Errorf("expected each key's prefix, got %q and %q", listed[0].Prefix, listed[1].Prefix)
+	}
+
+	if rr := doRequest(r, http.MethodDelete, testKey+"/view-keys/"+first.ID.String(), ""); rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if rr := doRequest(r, http.MethodGet, first.Key, ""); rr.Code != http.StatusNotFound {
+		t.Errorf("expected a revoked key to be unknown, got %d", rr.Code)
+	}
+	if rr := doRequest(r, http.MethodGet, second.Key, ""); rr.Code != http.StatusOK {
+		t.Errorf("expected the other key to keep working, got %d", rr.Code)
+	}
+	if rr := doRequest(r, http.MethodDelete, testKey+"/view-keys/"+first.ID.String(), ""); rr.Code != http.StatusNotFound {
+		t.Errorf("expected 404 revoking twice, got %d", rr.Code)
+	}
+}
+
+func TestViewKey_Limit(t *testing.T) {
+	r, br, _ := newTestRouter()
+	seedBoard(br, testKey, false)
+	for range maxViewKeysPerBoard {
+		createViewKey(t, r)
+	}
+	if rr := doRequest(r, http.MethodPost, testKey+"/view-keys", ""); rr.Code != http.StatusUnprocessableEntity {
+		t.Errorf("expected 422, got %d", rr.Code)
+	}
+}
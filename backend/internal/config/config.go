@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"strings"
+	"time"
 )
 
 // Config holds all the application configuration.
@@ -11,6 +12,11 @@ type Config struct {
 	Port           string
 	DatabaseURL    string
 	AllowedOrigins []string
+	// MaxBoardTTL caps the lifetime a board can be created or extended with.
+	MaxBoardTTL time.Duration
+	// BoardExpiryGrace is how long an expired board is kept before the
+	// reaper deletes it.
+	BoardExpiryGrace time.Duration
 }
 
 // Load reads configuration from environment variables.
@@ -37,9 +43,31 @@ func Load() *Config {
 		allowedOrigins[i] = strings.TrimSpace(o)
 	}
 
+	// MAX_BOARD_TTL is a Go duration such as 720h. Defaults to 30 days.
+	maxBoardTTL := 30 * 24 * time.Hour
+	if raw := os.Getenv("MAX_BOARD_TTL"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d <= 0 {
+			log.Fatalf("MAX_BOARD_TTL must be a positive duration such as 720h, got %q", raw)
+		}
+		maxBoardTTL = d
+	}
+
+	// BOARD_EXPIRY_GRACE is a Go duration such as 24h. Defaults to none.
+	var boardExpiryGrace time.Duration
+	if raw := os.Getenv("BOARD_EXPIRY_GRACE"); raw != "" {
+		d, err := time.ParseDuration(raw)
+		if err != nil || d < 0 {
+			log.Fatalf("BOARD_EXPIRY_GRACE must be a non-negative duration such as 24h, got %q", raw)
+		}
+		boardExpiryGrace = d
+	}
+
 	return &Config{
-		Port:           port,
-		DatabaseURL:    dbURL,
-		AllowedOrigins: allowedOrigins,
+		Port:             port,
+		DatabaseURL:      dbURL,
+		AllowedOrigins:   allowedOrigins,
+		MaxBoardTTL:      maxBoardTTL,
+		BoardExpiryGrace: boardExpiryGrace,
 	}
 }
@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Activity actions recorded for task changes.
+const (
+	ActivityTaskCreated  = "task.created"
+	ActivityTaskUpdated  = "task.updated"
+	ActivityTaskMoved    = "task.moved"
+	ActivityTaskDeleted  = "task.deleted"
+	ActivityTaskArchived = "task.archived"
+	ActivityTaskRestored = "task.restored"
+)
+
+// FieldChange holds the value of a single task field before and after a change.
+// Before is nil for creations and After is nil for deletions.
+type FieldChange struct {
+	Before interface{} `json:"before"`
+	After  interface{} `json:"after"`
+}
+
+// Activity is one entry in a board's audit trail.
+type Activity struct {
+	ID        int64                  `json:"id"`
+	BoardID   uuid.UUID              `json:"-"`
+	TaskID    uuid.UUID              `json:"task_id"`
+	Action    string                 `json:"action"`
+	Actor     string                 `json:"actor,omitempty"`
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+// ActivityRepository defines the interface for interacting with the activity log.
+type ActivityRepository interface {
+	Create(ctx context.Context, activity *Activity) error
+	// ListByBoardID returns up to limit entries, newest first. When beforeID is
+	// non-zero only entries with a smaller ID are returned.
+	ListByBoardID(ctx context.Context, boardID uuid.UUID, beforeID int64, limit int) ([]*Activity, error)
+}
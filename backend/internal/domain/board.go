@@ -2,24 +2,113 @@ package domain
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// Board represents a kanban board.
+// ErrExpiryInProgress is returned by DeleteExpired while another server is
+// already deleting expired boards.
+var ErrExpiryInProgress = errors.New("expired boards are already being deleted")
+
+// Board represents a kanban board. Key is the board's secret; only a hash of
+// it is stored, so Key is set only on a board that has just been created.
 type Board struct {
 	ID        uuid.UUID `json:"-"`
-	Key       string    `json:"key"`
+	Key       string    `json:"key,omitempty"`
 	Title     string    `json:"title"`
 	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 	ExpiresAt time.Time `json:"expires_at"`
+	Lanes     []*Lane   `json:"lanes,omitempty"`
+
+	// EnforceDependencies refuses moving a task into the board's last lane
+	// while any task blocking it is still outside that lane.
+	EnforceDependencies bool `json:"enforce_dependencies"`
+
+	// TTLSeconds is the board's lifetime: ExpiresAt is that long after the
+	// board was created or last extended. With SlidingExpiry, every change
+	// to the board also pushes ExpiresAt to TTLSeconds from the change.
+	TTLSeconds    int  `json:"ttl_seconds"`
+	SlidingExpiry bool `json:"sliding_expiry"`
+}
+
+// Lane is a board-owned column. A task's Status holds the name of the lane it
+// sits in, and lanes are displayed in ascending Position order.
+//
+// WIPLimit caps how many tasks the lane may hold; zero means unlimited.
+type Lane struct {
+	ID       uuid.UUID  `json:"id"`
+	BoardID  uuid.UUID  `json:"-"`
+	Name     TaskStatus `json:"name"`
+	Position int        `json:"position"`
+	WIPLimit int        `json:"wip_limit"`
+}
+
+// Access is what a board key lets its holder do.
+type Access string
+
+const (
+	// AccessEdit is granted by the board's own key.
+	AccessEdit Access = "edit"
+	// AccessView is granted by a view key: the board can be read but not
+	// changed.
+	AccessView Access = "view"
+)
+
+// ViewKey is a secondary key that grants read-only access to a board, for
+// sharing it where the board's own key would be unsafe. Like a board key, Key
+// is only known when the view key is created; afterwards Prefix, its first
+// few characters, tells view keys apart.
+type ViewKey struct {
+	ID        uuid.UUID `json:"id"`
+	BoardID   uuid.UUID `json:"-"`
+	Key       string    `json:"key,omitempty"`
+	Prefix    string    `json:"prefix"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
+// DefaultLanes are the lanes given to a board when none are specified at creation.
+var DefaultLanes = []TaskStatus{StatusTodo, StatusInProgress, StatusDone}
+
 // BoardRepository defines the interface for interacting with board data.
 type BoardRepository interface {
+	// Create inserts the board together with its initial Lanes.
 	Create(ctx context.Context, board *Board) error
+	// GetByKey finds a board by the hash of its key.
 	GetByKey(ctx context.Context, key string) (*Board, error)
 	GetByID(ctx context.Context, id uuid.UUID) (*Board, error)
+	// Update persists the board's title, settings, lifetime and UpdatedAt.
+	Update(ctx context.Context, board *Board) error
+	// SlideExpiry pushes the expiry of the board with this key to TTLSeconds
+	// after now, if the board uses sliding expiry and has not yet expired.
+	// UpdatedAt is set to now along with it.
+	SlideExpiry(ctx context.Context, key string, now time.Time) error
 	DeleteByKey(ctx context.Context, key string) error
+	// DeleteExpired deletes every board that expired before the given time,
+	// with everything on it, and returns the deleted boards' IDs. Only one
+	// caller across all servers deletes at a time; the others get
+	// ErrExpiryInProgress.
+	DeleteExpired(ctx context.Context, before time.Time) ([]uuid.UUID, error)
+
+	// ListLanes returns the board's lanes ordered by position.
+	ListLanes(ctx context.Context, boardID uuid.UUID) ([]*Lane, error)
+	CreateLane(ctx context.Context, lane *Lane) error
+	// UpdateLane stores the lane's name and WIP limit in one transaction,
+	// moving every task in oldName along with a renamed lane, and assigns
+	// positions 0..n-1 to laneIDs when it is non-nil. Lowering the WIP limit
+	// below the lane's current task count is allowed; it only blocks further
+	// tasks.
+	UpdateLane(ctx context.Context, lane *Lane, oldName TaskStatus, laneIDs []uuid.UUID) error
+	// ReorderLanes assigns positions 0..n-1 to laneIDs in the given order.
+	ReorderLanes(ctx context.Context, boardID uuid.UUID, laneIDs []uuid.UUID) error
+	DeleteLane(ctx context.Context, id uuid.UUID) error
+
+	// GetByViewKey returns the board a view key was issued for.
+	GetByViewKey(ctx context.Context, key string) (*Board, error)
+	CreateViewKey(ctx context.Context, viewKey *ViewKey) error
+	// ListViewKeys returns the board's view keys, oldest first, without Key.
+	ListViewKeys(ctx context.Context, boardID uuid.UUID) ([]*ViewKey, error)
+	DeleteViewKey(ctx context.Context, id uuid.UUID) error
 }
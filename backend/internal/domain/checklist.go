@@ -0,0 +1,40 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ChecklistItem is a single step within a task's ordered checklist.
+type ChecklistItem struct {
+	ID        uuid.UUID `json:"id"`
+	TaskID    uuid.UUID `json:"-"`
+	Text      string    `json:"text"`
+	Done      bool      `json:"done"`
+	Position  int       `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// ChecklistSummary reports checklist completion for a task, e.g. 3 of 5 done.
+type ChecklistSummary struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// ChecklistRepository defines the interface for interacting with checklist data.
+type ChecklistRepository interface {
+	Create(ctx context.Context, item *ChecklistItem) error
+	GetByID(ctx context.Context, id uuid.UUID) (*ChecklistItem, error)
+	// ListByTaskID returns the task's items ordered by position.
+	ListByTaskID(ctx context.Context, taskID uuid.UUID) ([]*ChecklistItem, error)
+	Update(ctx context.Context, item *ChecklistItem) error
+	// Reorder assigns positions 0..n-1 to itemIDs in the given order.
+	Reorder(ctx context.Context, taskID uuid.UUID, itemIDs []uuid.UUID) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	// SummarizeByBoardID returns completion counts keyed by task ID for every
+	// task on the board that has at least one checklist item.
+	SummarizeByBoardID(ctx context.Context, boardID uuid.UUID) (map[uuid.UUID]ChecklistSummary, error)
+}
@@ -0,0 +1,28 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Comment is an entry in a task's append-only discussion thread.
+type Comment struct {
+	ID        uuid.UUID `json:"id"`
+	TaskID    uuid.UUID `json:"-"`
+	Author    string    `json:"author"`
+	Body      string    `json:"body"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// CommentRepository defines the interface for interacting with comment data.
+// Comments are never edited; they can only be added or deleted.
+type CommentRepository interface {
+	Create(ctx context.Context, comment *Comment) error
+	GetByID(ctx context.Context, id uuid.UUID) (*Comment, error)
+	// ListByTaskID returns the task's comments oldest first.
+	ListByTaskID(ctx context.Context, taskID uuid.UUID) ([]*Comment, error)
+	CountByTaskID(ctx context.Context, taskID uuid.UUID) (int, error)
+	Delete(ctx context.Context, id uuid.UUID) error
+}
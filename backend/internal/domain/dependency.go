@@ -0,0 +1,54 @@
+package domain
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// Dependency is a directed edge meaning TaskID cannot be finished until
+// BlockedByID is. Both tasks belong to the same board.
+type Dependency struct {
+	TaskID      uuid.UUID `json:"task_id"`
+	BlockedByID uuid.UUID `json:"blocked_by"`
+}
+
+// ErrDependencyCycle is returned by DependencyRepository.Create when the
+// blocker already depends, directly or transitively, on the task.
+var ErrDependencyCycle = errors.New("dependency would create a cycle")
+
+// DependsOn reports whether from is blocked, directly or transitively, by target.
+func DependsOn(deps []*Dependency, from, target uuid.UUID) bool {
+	blockers := make(map[uuid.UUID][]uuid.UUID)
+	for _, d := range deps {
+		blockers[d.TaskID] = append(blockers[d.TaskID], d.BlockedByID)
+	}
+
+	visited := make(map[uuid.UUID]bool)
+	stack := []uuid.UUID{from}
+	for len(stack) > 0 {
+		id := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		if id == target {
+			return true
+		}
+		if visited[id] {
+			continue
+		}
+		visited[id] = true
+		stack = append(stack, blockers[id]...)
+	}
+	return false
+}
+
+// DependencyRepository defines the interface for interacting with task dependency data.
+type DependencyRepository interface {
+	// Create adds the edge to a task on the board. It holds the board's lock
+	// while checking the existing edges, and returns ErrDependencyCycle
+	// instead if the edge would close a cycle.
+	Create(ctx context.Context, boardID uuid.UUID, dep *Dependency) error
+	Delete(ctx context.Context, taskID, blockedByID uuid.UUID) error
+	// ListByBoardID returns every dependency edge between tasks on the board.
+	ListByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Dependency, error)
+}
@@ -0,0 +1,36 @@
+package domain
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// IdempotentResponse is the response stored for an Idempotency-Key, replayed
+// to retries of the same request. Status is 0 while the first request is
+// still being handled. Body is encrypted by the caller before it is stored.
+type IdempotentResponse struct {
+	Fingerprint string
+	Status      int
+	Header      map[string]string
+	Body        []byte
+}
+
+// IdempotencyRepository stores responses by the hash of an idempotency key.
+type IdempotencyRepository interface {
+	// Claim reserves key for a request with the given fingerprint and returns
+	// a token identifying the claim. If the key is already held by a record
+	// created after notBefore, that record is returned instead; older records
+	// are replaced, as are claims still in progress that were made before
+	// leaseBefore.
+	Claim(ctx context.Context, key, fingerprint string, notBefore, leaseBefore time.Time) (uuid.UUID, *IdempotentResponse, error)
+	// Complete stores the response for key if the claim with the given token
+	// still holds it. A claim that has since been taken over is left alone.
+	Complete(ctx context.Context, key string, token uuid.UUID, resp *IdempotentResponse) error
+	// Release drops the claim with the given token so that the request can
+	// be retried afresh.
+	Release(ctx context.Context, key string, token uuid.UUID) error
+	// DeleteBefore removes records created before t and returns how many.
+	DeleteBefore(ctx context.Context, t time.Time) (int64, error)
+}
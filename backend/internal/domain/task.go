@@ -2,38 +2,248 @@ package domain
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
 )
 
-// TaskStatus represents the current state of a task.
+// TaskStatus is the name of the lane a task currently sits in.
 type TaskStatus string
 
+// Names of the lanes created for a board by default.
 const (
 	StatusTodo       TaskStatus = "TODO"
 	StatusInProgress TaskStatus = "IN_PROGRESS"
 	StatusDone       TaskStatus = "DONE"
 )
 
-// Task represents an item of work on a board.
+// ErrWIPLimitReached is returned by TaskRepository.Create and Update when the
+// task would enter a lane that already holds its WIP limit of tasks.
+var ErrWIPLimitReached = errors.New("lane WIP limit reached")
+
+// ErrNeighbourNotInLane is returned by TaskRepository.Move when the neighbour
+// is not an unarchived task in the lane the task is moving to.
+var ErrNeighbourNotInLane = errors.New("neighbour task is not in the target lane")
+
+// ErrVersionConflict is returned by TaskRepository.Update when the stored
+// task's Version no longer matches the one being written, meaning someone
+// else changed the task since it was read.
+var ErrVersionConflict = errors.New("task version conflict")
+
+// TaskPriority ranks how urgent a task is. The empty value means no priority.
+type TaskPriority string
+
+const (
+	PriorityNone   TaskPriority = ""
+	PriorityLow    TaskPriority = "LOW"
+	PriorityMedium TaskPriority = "MEDIUM"
+	PriorityHigh   TaskPriority = "HIGH"
+	PriorityUrgent TaskPriority = "URGENT"
+)
+
+// Rank orders priorities from 0 (none) to 4 (urgent), or returns -1 if p is
+// not a known priority.
+func (p TaskPriority) Rank() int {
+	switch p {
+	case PriorityNone:
+		return 0
+	case PriorityLow:
+		return 1
+	case PriorityMedium:
+		return 2
+	case PriorityHigh:
+		return 3
+	case PriorityUrgent:
+		return 4
+	}
+	return -1
+}
+
+// TaskSort selects the order of tasks returned by GetByBoardID.
+type TaskSort string
+
+const (
+	// SortByLane orders tasks by lane, then rank within the lane.
+	SortByLane TaskSort = ""
+	// SortByPriority orders tasks by descending priority, then earliest due date.
+	SortByPriority TaskSort = "priority"
+)
+
+// Task represents an item of work on a board. DueAt is optional; a task is
+// overdue once DueAt has passed and it is not yet in the board's last lane.
+// An archived task has ArchivedAt set; it is hidden from board reads by
+// default and does not count toward task or WIP limits.
+//
+// Rank orders the unarchived tasks of a lane: it is an opaque string, and
+// tasks sort by comparing ranks bytewise. Position is the task's zero-based
+// index within its lane, derived from the ranks when the task is read.
+//
+// Version starts at 1 and increases by one with every stored change; it is
+// the basis of the task's ETag.
 type Task struct {
-	ID          uuid.UUID  `json:"id"`
-	BoardID     uuid.UUID  `json:"-"`
-	Title       string     `json:"title"`
-	Description string     `json:"description"`
-	Status      TaskStatus `json:"status"`
-	Position    int        `json:"position"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID          uuid.UUID    `json:"id"`
+	BoardID     uuid.UUID    `json:"-"`
+	Title       string       `json:"title"`
+	Description string       `json:"description"`
+	Status      TaskStatus   `json:"status"`
+	Rank        string       `json:"rank"`
+	Position    int          `json:"position"`
+	Labels      []string     `json:"labels"`
+	Assignee    string       `json:"assignee"`
+	DueAt       *time.Time   `json:"due_at"`
+	Priority    TaskPriority `json:"priority"`
+	ArchivedAt  *time.Time   `json:"archived_at,omitempty"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+	Version     int          `json:"version"`
+
+	// Checklist is filled in on board reads when the task has checklist items.
+	Checklist *ChecklistSummary `json:"checklist,omitempty"`
+	// BlockedBy and Blocks are filled in on board reads from the board's
+	// dependency edges.
+	BlockedBy []uuid.UUID `json:"blocked_by,omitempty"`
+	Blocks    []uuid.UUID `json:"blocks,omitempty"`
+}
+
+// TaskSearchResult is a task matched by a full-text search. Score is the
+// match's relevance, higher being better; the task's own Rank is its place in
+// its lane. Snippet is an HTML-escaped excerpt of the title and description
+// in which the matched terms are wrapped in <mark> tags.
+type TaskSearchResult struct {
+	*Task
+	Score   float64 `json:"score"`
+	Snippet string  `json:"snippet"`
+}
+
+// Neighbour places a task directly before, or with After directly after,
+// the task ID in the same lane.
+type Neighbour struct {
+	ID    uuid.UUID
+	After bool
+}
+
+// TaskWrite is one step of TaskRepository.ApplyBatch. Task is inserted when
+// Create is set and otherwise overwrites the stored task with the same ID,
+// as Update does, or as Move does when Neighbour is set.
+type TaskWrite struct {
+	Create    bool
+	Task      *Task
+	Neighbour *Neighbour
+}
+
+// BatchError reports which write of a batch failed. Nothing in the batch is
+// persisted when it is returned.
+type BatchError struct {
+	Index int
+	Err   error
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("batch write %d: %v", e.Index, e.Err)
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Err
+}
+
+// UnlanedPosition stands in for the lane position of a task whose status
+// matches none of the board's lanes, so that such tasks sort last.
+const UnlanedPosition = math.MaxInt32
+
+// TaskCursor is the sort key of a task in GetByBoardID results. Passed back
+// as TaskFilter.After, it starts the next page after that task. PriorityRank
+// and DueAt are only compared when sorting by priority.
+type TaskCursor struct {
+	LanePosition int
+	Status       TaskStatus
+	Rank         string
+	ID           uuid.UUID
+	PriorityRank int
+	DueAt        *time.Time
+}
+
+// NewTaskCursor returns the sort key of task on a board with the given lanes.
+func NewTaskCursor(task *Task, lanes []*Lane) TaskCursor {
+	c := TaskCursor{
+		LanePosition: UnlanedPosition,
+		Status:       task.Status,
+		Rank:         task.Rank,
+		ID:           task.ID,
+		PriorityRank: task.Priority.Rank(),
+		DueAt:        task.DueAt,
+	}
+	for _, l := range lanes {
+		if l.Name == task.Status {
+			c.LanePosition = l.Position
+		}
+	}
+	return c
+}
+
+// TaskFilter narrows the tasks returned by GetByBoardID. Zero values match every task.
+type TaskFilter struct {
+	// Statuses restricts results to tasks in any of the listed lanes.
+	Statuses []TaskStatus
+	// Labels restricts results to tasks carrying every listed label.
+	Labels []string
+	// Assignee, when non-nil, restricts results to tasks with exactly this
+	// assignee; an empty string matches unassigned tasks.
+	Assignee *string
+	// Overdue restricts results to tasks whose due date has passed and that are
+	// not in the board's last lane.
+	Overdue bool
+	// IncludeArchived also returns archived tasks, which are skipped by default.
+	IncludeArchived bool
+	Sort            TaskSort
+	// After, when non-nil, skips tasks sorting at or before the cursor.
+	After *TaskCursor
+	// Limit caps the number of tasks returned; 0 means no limit.
+	Limit int
 }
 
 // TaskRepository defines the interface for interacting with task data.
 type TaskRepository interface {
+	// Create and Update return ErrWIPLimitReached when the task would enter a
+	// full lane, including when Update restores an archived task. The check
+	// and the write happen in one transaction.
+	//
+	// Create appends the task to the end of its lane. Update moves the task to
+	// index Position of its lane when its lane, position or archived state
+	// changes by giving it a new rank; other tasks keep theirs. The stored
+	// Rank and Position are written back to task.
 	Create(ctx context.Context, task *Task) error
 	GetByID(ctx context.Context, id uuid.UUID) (*Task, error)
-	GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*Task, error)
+	GetByBoardID(ctx context.Context, boardID uuid.UUID, filter TaskFilter) ([]*Task, error)
+	// Update stores the task only if the stored Version still equals
+	// task.Version, returning ErrVersionConflict otherwise. On success it
+	// increments task.Version.
 	Update(ctx context.Context, task *Task) error
+	// Move is Update, except that the task is placed next to the neighbour
+	// instead of at Position. It returns ErrNeighbourNotInLane if the
+	// neighbour is not an unarchived task in the task's lane.
+	Move(ctx context.Context, task *Task, neighbour Neighbour) error
+	// ApplyBatch performs writes in order inside one transaction. On failure
+	// it rolls back and returns a *BatchError naming the failing write. Only
+	// the first write to a task is version-checked; later writes to the same
+	// task build on it.
+	ApplyBatch(ctx context.Context, writes []TaskWrite) error
+	// RebalanceRanks gives every lane holding a rank longer than maxLength
+	// evenly spaced short ranks in the same order, one lane per transaction,
+	// and returns the IDs of the boards it changed.
+	RebalanceRanks(ctx context.Context, maxLength int) ([]uuid.UUID, error)
+	// Delete removes the task permanently. Archiving is an Update of ArchivedAt.
 	Delete(ctx context.Context, id uuid.UUID) error
+	// CountByBoardID skips archived tasks. CountByStatus counts them too,
+	// since an archived task keeps its lane and can be restored into it.
 	CountByBoardID(ctx context.Context, boardID uuid.UUID) (int, error)
+	CountByStatus(ctx context.Context, boardID uuid.UUID, status TaskStatus) (int, error)
+	// Search returns up to limit unarchived tasks on the board matching query,
+	// best match first.
+	Search(ctx context.Context, boardID uuid.UUID, query string, limit int) ([]*TaskSearchResult, error)
+	// ListAssignees returns the distinct, non-empty assignees on a board's
+	// unarchived tasks, sorted.
+	ListAssignees(ctx context.Context, boardID uuid.UUID) ([]string, error)
 }
@@ -0,0 +1,183 @@
+// Package events fans out board change notifications to live subscribers,
+// such as the Server-Sent Events stream.
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// replaySize is how many recent events are kept per board for resuming
+	// with Last-Event-ID.
+	replaySize = 256
+	// subscriberBuffer is how many events may queue for a slow subscriber
+	// before it is disconnected. It can reconnect and resume from its last ID.
+	subscriberBuffer = 64
+	// idleStreamTTL is how long a board with no subscribers keeps its recent
+	// events. Clients resuming after that are told to reload instead.
+	idleStreamTTL = 10 * time.Minute
+)
+
+// Event is a single change on a board. IDs increase strictly across all
+// boards and, because they are seeded from the clock, across restarts.
+type Event struct {
+	ID   uint64
+	Type string
+	Data json.RawMessage
+}
+
+type stream struct {
+	recent []Event
+	subs   map[chan Event]struct{}
+	// floor is the newest ID whose event may be missing from recent, either
+	// because it was evicted or because it predates the stream. Only clients
+	// resuming from floor or later can be replayed completely.
+	floor    uint64
+	lastUsed time.Time
+}
+
+// Hub keeps recent events and live subscribers per board. It is safe for
+// concurrent use.
+type Hub struct {
+	mu        sync.Mutex
+	lastID    uint64
+	streams   map[uuid.UUID]*stream
+	lastPrune time.Time
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		lastID:    uint64(time.Now().UnixNano()),
+		streams:   make(map[uuid.UUID]*stream),
+		lastPrune: time.Now(),
+	}
+}
+
+func (h *Hub) stream(boardID uuid.UUID) *stream {
+	s, ok := h.streams[boardID]
+	if !ok {
+		s = &stream{subs: make(map[chan Event]struct{}), floor: h.lastID}
+		h.streams[boardID] = s
+	}
+	s.lastUsed = time.Now()
+	return s
+}
+
+// Publish records an event for the board and delivers it to every subscriber.
+// data is encoded as JSON.
+func (h *Hub) Publish(boardID uuid.UUID, eventType string, data interface{}) error {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.pruneLocked()
+
+	h.lastID++
+	event := Event{ID: h.lastID, Type: eventType, Data: raw}
+	s := h.stream(boardID)
+	s.recent = append(s.recent, event)
+	if len(s.recent) > replaySize {
+		s.floor = s.recent[0].ID
+		s.recent = s.recent[1:]
+	}
+	for ch := range s.subs {
+		select {
+		case ch <- event:
+		default:
+			// Too slow to keep up; dropping it lets the client resume cleanly.
+			delete(s.subs, ch)
+			close(ch)
+		}
+	}
+	return nil
+}
+
+// pruneLocked forgets boards that have had no subscribers or events for
+// idleStreamTTL. It runs at most once a minute.
+func (h *Hub) pruneLocked() {
+	now := time.Now()
+	if now.Sub(h.lastPrune) < time.Minute {
+		return
+	}
+	h.lastPrune = now
+	for id, s := range h.streams {
+		if len(s.subs) == 0 && now.Sub(s.lastUsed) > idleStreamTTL {
+			delete(h.streams, id)
+		}
+	}
+}
+
+// Subscription is a live registration for one board's events.
+type Subscription struct {
+	// Replay holds the events published after the requested ID.
+	Replay []Event
+	// Complete is false when events after the requested ID may have been
+	// discarded, in which case the client should reload the board.
+	Complete bool
+	// LastID is the newest event ID at the time of subscribing.
+	LastID uint64
+	// Events delivers new events. It is closed when the subscriber falls
+	// behind, when the board is closed, or by Cancel.
+	Events <-chan Event
+
+	cancel func()
+}
+
+// Cancel ends the subscription. It is safe to call more than once.
+func (s *Subscription) Cancel() {
+	s.cancel()
+}
+
+// Subscribe registers for the board's events. A non-zero lastID asks for the
+// events published after it to be replayed.
+func (h *Hub) Subscribe(boardID uuid.UUID, lastID uint64) *Subscription {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s := h.stream(boardID)
+	sub := &Subscription{Complete: true, LastID: h.lastID}
+	if lastID != 0 {
+		sub.Complete = lastID >= s.floor && lastID <= h.lastID
+		for _, e := range s.recent {
+			if e.ID > lastID {
+				sub.Replay = append(sub.Replay, e)
+			}
+		}
+	}
+
+	ch := make(chan Event, subscriberBuffer)
+	s.subs[ch] = struct{}{}
+	sub.Events = ch
+	sub.cancel = func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := s.subs[ch]; ok {
+			delete(s.subs, ch)
+			close(ch)
+		}
+	}
+	return sub
+}
+
+// Close ends every subscription to the board and forgets its events.
+func (h *Hub) Close(boardID uuid.UUID) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.streams[boardID]
+	if !ok {
+		return
+	}
+	for ch := range s.subs {
+		delete(s.subs, ch)
+		close(ch)
+	}
+	delete(h.streams, boardID)
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestHub_DeliversToBoardSubscribersOnly(t *testing.T) {
+	h := NewHub()
+	board, other := uuid.New(), uuid.New()
+	sub := h.Subscribe(board, 0)
+	defer sub.Cancel()
+
+	h.Publish(other, "task.created", map[string]string{"id": "x"})
+	h.Publish(board, "task.created", map[string]string{"id": "y"})
+
+	e := <-sub.Events
+	if e.Type != "task.created" || string(e.Data) != `{"id":"y"}` {
+		t.Errorf("unexpected event %s %s", e.Type, e.Data)
+	}
+	if len(sub.Events) != 0 {
+		t.Error("expected no events from other boards")
+	}
+}
+
+func TestHub_ReplaysAfterLastID(t *testing.T) {
+	h := NewHub()
+	board := uuid.New()
+	first := h.Subscribe(board, 0)
+	h.Publish(board, "a", nil)
+	h.Publish(board, "b", nil)
+	h.Publish(board, "c", nil)
+	seen := <-first.Events
+	first.Cancel()
+
+	sub := h.Subscribe(board, seen.ID)
+	defer sub.Cancel()
+	if !sub.Complete {
+		t.Fatal("expected a complete replay")
+	}
+	if len(sub.Replay) != 2 || sub.Replay[0].Type != "b" || sub.Replay[1].Type != "c" {
+		t.Errorf("expected replay of b and c, got %+v", sub.Replay)
+	}
+}
+
+func TestHub_IncompleteReplay(t *testing.T) {
+	h := NewHub()
+	board := uuid.New()
+
+	// An ID from before the hub existed, e.g. a previous server process.
+	if sub := h.Subscribe(board, 1); sub.Complete {
+		t.Error("expected an unknown old ID to be incomplete")
+	}
+
+	first := h.Subscribe(board, 0)
+	for i := 0; i < replaySize+1; i++ {
+		h.Publish(board, "e", nil)
+	}
+	if sub := h.Subscribe(board, first.LastID); sub.Complete {
+		t.Error("expected an evicted ID to be incomplete")
+	}
+}
+
+func TestHub_SlowSubscriberIsDropped(t *testing.T) {
+	h := NewHub()
+	board := uuid.New()
+	sub := h.Subscribe(board, 0)
+	for i := 0; i < subscriberBuffer+1; i++ {
+		h.Publish(board, "e", nil)
+	}
+	n := 0
+	for range sub.Events {
+		n++
+	}
+	if n != subscriberBuffer {
+		t.Errorf("expected %d buffered events before close, got %d", subscriberBuffer, n)
+	}
+	sub.Cancel() // must not panic after the hub closed the channel
+}
+
+func TestHub_CloseEndsSubscriptions(t *testing.T) {
+	h := NewHub()
+	board := uuid.New()
+	sub := h.Subscribe(board, 0)
+	h.Close(board)
+	if _, ok := <-sub.Events; ok {
+		t.Error("expected the channel to be closed")
+	}
+	sub.Cancel()
+}
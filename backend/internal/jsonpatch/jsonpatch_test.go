@@ -0,0 +1,104 @@
+package jsonpatch
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) interface{} {
+	t.Helper()
+	var v interface{}
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("bad JSON %s: %v", s, err)
+	}
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	cases := []struct{ doc, patch, want string }{
+		{`{"a":"b"}`, `{"a":"c"}`, `{"a":"c"}`},
+		{`{"a":"b"}`, `{"b":"c"}`, `{"a":"b","b":"c"}`},
+		{`{"a":"b"}`, `{"a":null}`, `{}`},
+		{`{"a":["b"]}`, `{"a":["c","d"]}`, `{"a":["c","d"]}`},
+		{`{"a":{"b":"c"}}`, `{"a":{"b":"d","c":null}}`, `{"a":{"b":"d"}}`},
+		{`{"a":"b"}`, `["c"]`, `["c"]`},
+		{`["a"]`, `{"b":"c"}`, `{"b":"c"}`},
+	}
+	for _, c := range cases {
+		got := MergePatch(decode(t, c.doc), decode(t, c.patch))
+		if !reflect.DeepEqual(got, decode(t, c.want)) {
+			t.Errorf("MergePatch(%s, %s) = %v, want %s", c.doc, c.patch, got, c.want)
+		}
+	}
+}
+
+func TestApply(t *testing.T) {
+	cases := []struct{ doc, patch, want string }{
+		{`{"a":1}`, `[{"op":"add","path":"/b","value":null}]`, `{"a":1,"b":null}`},
+		{`{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{`{"a":[1]}`, `[{"op":"add","path":"/a/-","value":2}]`, `{"a":[1,2]}`},
+		{`{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/0"}]`, `{"a":[2,3]}`},
+		{`{"a":{"b":1}}`, `[{"op":"replace","path":"/a/b","value":"x"}]`, `{"a":{"b":"x"}}`},
+		{`{"a":1,"b":{}}`, `[{"op":"move","from":"/a","path":"/b/c"}]`, `{"b":{"c":1}}`},
+		{`{"a":[1]}`, `[{"op":"copy","from":"/a","path":"/b"},{"op":"add","path":"/b/-","value":2}]`, `{"a":[1],"b":[1,2]}`},
+		{`{"a/b":1,"m~n":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"test","path":"/m~0n","value":2}]`, `{"m~n":2}`},
+		{`{"a":1}`, `[{"op":"replace","path":"","value":[1]}]`, `[1]`},
+	}
+	for _, c := range cases {
+		ops, err := Parse([]byte(c.patch))
+		if err != nil {
+			t.Fatalf("Parse(%s): %v", c.patch, err)
+		}
+		got, err := Apply(decode(t, c.doc), ops)
+		if err != nil {
+			t.Errorf("Apply(%s, %s): %v", c.doc, c.patch, err)
+			continue
+		}
+		if !reflect.DeepEqual(got, decode(t, c.want)) {
+			t.Errorf("Apply(%s, %s) = %v, want %s", c.doc, c.patch, got, c.want)
+		}
+	}
+}
+
+func TestApply_Errors(t *testing.T) {
+	cases := []struct {
+		doc, patch string
+		invalid    bool
+		testFailed bool
+	}{
+		{`{"a":1}`, `[{"op":"test","path":"/a","value":2}]`, false, true},
+		{`{"a":"1"}`, `[{"op":"test","path":"/a","value":1}]`, false, true},
+		{`{"a":1}`, `[{"op":"frobnicate","path":"/a"}]`, true, false},
+		{`{"a":1}`, `[{"op":"add","path":"/b"}]`, true, false},
+		{`{"a":1}`, `[{"op":"add","path":"b","value":1}]`, true, false},
+		{`{"a":{}}`, `[{"op":"move","from":"/a","path":"/a/b"}]`, true, false},
+		{`{"a":1}`, `[{"op":"remove","path":"/b"}]`, false, false},
+		{`{"a":1}`, `[{"op":"replace","path":"/b","value":1}]`, false, false},
+		{`{"a":[1]}`, `[{"op":"add","path":"/a/2","value":1}]`, false, false},
+		{`{"a":[1]}`, `[{"op":"remove","path":"/a/01"}]`, false, false},
+		{`{"a":[1]}`, `[{"op":"remove","path":"/a/-"}]`, false, false},
+		{`{"a":1}`, `[{"op":"add","path":"/a/b","value":1}]`, false, false},
+	}
+	for _, c := range cases {
+		ops, err := Parse([]byte(c.patch))
+		if err == nil {
+			_, err = Apply(decode(t, c.doc), ops)
+		}
+		if err == nil {
+			t.Errorf("Apply(%s, %s): expected an error", c.doc, c.patch)
+			continue
+		}
+		if got := errors.Is(err, ErrInvalidPatch); got != c.invalid {
+			t.Errorf("Apply(%s, %s): ErrInvalidPatch = %v, want %v (%v)", c.doc, c.patch, got, c.invalid, err)
+		}
+		if got := errors.Is(err, ErrTestFailed); got != c.testFailed {
+			t.Errorf("Apply(%s, %s): ErrTestFailed = %v, want %v (%v)", c.doc, c.patch, got, c.testFailed, err)
+		}
+	}
+
+	if _, err := Parse([]byte(`{"op":"add"}`)); !errors.Is(err, ErrInvalidPatch) {
+		t.Errorf("expected a non-array patch to be invalid, got %v", err)
+	}
+}
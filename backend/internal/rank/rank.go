@@ -0,0 +1,66 @@
+// Package rank generates lexicographic ranks: strings whose byte order is the
+// order of the items they label. A new rank can always be made between any
+// two others, so an item can be moved without renumbering its neighbours.
+package rank
+
+import "strings"
+
+// digits are the rank alphabet in ascending byte order. Ranks are compared
+// bytewise, so the database column holding them must use the C collation.
+const digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+const base = len(digits)
+
+// Between returns a rank that sorts strictly after a and before b. An empty a
+// means before every rank and an empty b after every rank. a must sort
+// before b. The result never ends in the lowest digit, which keeps room
+// before every rank.
+func Between(a, b string) string {
+	var out strings.Builder
+	bounded := b != ""
+	for i := 0; ; i++ {
+		lo := 0
+		if i < len(a) {
+			lo = strings.IndexByte(digits, a[i])
+		}
+		hi := base
+		if bounded && i < len(b) {
+			hi = strings.IndexByte(digits, b[i])
+		}
+
+		switch {
+		case hi-lo > 1:
+			out.WriteByte(digits[(lo+hi)/2])
+			return out.String()
+		case hi-lo == 1:
+			// Any continuation of a's digit now sorts before b.
+			out.WriteByte(digits[lo])
+			bounded = false
+		default:
+			out.WriteByte(digits[lo])
+		}
+	}
+}
+
+// Spread returns n ascending ranks spaced evenly across the rank space, as
+// short as possible, for relabelling items whose ranks have grown long.
+func Spread(n int) []string {
+	width, slots := 1, base
+	for slots <= n {
+		width++
+		slots *= base
+	}
+	step := slots / (n + 1)
+
+	ranks := make([]string, n)
+	buf := make([]byte, width)
+	for i := range ranks {
+		v := (i + 1) * step
+		for j := width - 1; j >= 0; j-- {
+			buf[j] = digits[v%base]
+			v /= base
+		}
+		ranks[i] = strings.TrimRight(string(buf), digits[:1])
+	}
+	return ranks
+}
@@ -0,0 +1,77 @@
+package rank
+
+import (
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+)
+
+func TestBetween(t *testing.T) {
+	cases := []struct{ a, b string }{
+		{"", ""},
+		{"i", ""},
+		{"", "i"},
+		{"", "1"},
+		{"1", "2"},
+		{"1", "15"},
+		{"a", "b"},
+		{"az", "b"},
+		{"zz", ""},
+		{"0i", "1"},
+	}
+	for _, c := range cases {
+		got := Between(c.a, c.b)
+		if got <= c.a || (c.b != "" && got >= c.b) {
+			t.Errorf("Between(%q, %q) = %q, not strictly between", c.a, c.b, got)
+		}
+		if strings.HasSuffix(got, "0") {
+			t.Errorf("Between(%q, %q) = %q ends in the lowest digit", c.a, c.b, got)
+		}
+	}
+}
+
+func TestBetween_RepeatedInsertsStayOrdered(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	ranks := []string{Between("", "")}
+	for i := 0; i < 500; i++ {
+		at := rng.Intn(len(ranks) + 1)
+		var a, b string
+		if at > 0 {
+			a = ranks[at-1]
+		}
+		if at < len(ranks) {
+			b = ranks[at]
+		}
+		r := Between(a, b)
+		ranks = append(ranks[:at], append([]string{r}, ranks[at:]...)...)
+	}
+	if !sort.StringsAreSorted(ranks) {
+		t.Fatal("expected ranks to stay sorted")
+	}
+	for i := 1; i < len(ranks); i++ {
+		if ranks[i] == ranks[i-1] {
+			t.Fatalf("duplicate rank %q", ranks[i])
+		}
+	}
+}
+
+func TestSpread(t *testing.T) {
+	for _, n := range []int{0, 1, 35, 36, 100, 2000} {
+		ranks := Spread(n)
+		if len(ranks) != n {
+			t.Fatalf("Spread(%d): expected %d ranks, got %d", n, n, len(ranks))
+		}
+		for i, r := range ranks {
+			if r == "" || strings.HasSuffix(r, "0") {
+				t.Errorf("Spread(%d)[%d] = %q is not a valid rank", n, i, r)
+			}
+			if i > 0 && r <= ranks[i-1] {
+				t.Errorf("Spread(%d): %q does not sort after %q", n, r, ranks[i-1])
+			}
+		}
+	}
+	if got := Spread(100); len(got[99]) > 2 {
+		t.Errorf("expected two-digit ranks for 100 items, got %q", got[99])
+	}
+}
@@ -0,0 +1,53 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+type ActivityRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewActivityRepository(db *pgxpool.Pool) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+func (r *ActivityRepository) Create(ctx context.Context, activity *domain.Activity) error {
+	query := `
+		INSERT INTO board_activity (board_id, task_id, action, actor, changes, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		activity.BoardID, activity.TaskID, activity.Action, activity.Actor, activity.Changes, activity.CreatedAt,
+	).Scan(&activity.ID)
+}
+
+func (r *ActivityRepository) ListByBoardID(ctx context.Context, boardID uuid.UUID, beforeID int64, limit int) ([]*domain.Activity, error) {
+	query := `
+		SELECT id, board_id, task_id, action, actor, changes, created_at
+		FROM board_activity
+		WHERE board_id = $1 AND ($2 = 0 OR id < $2)
+		ORDER BY id DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, boardID, beforeID, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*domain.Activity
+	for rows.Next() {
+		a := &domain.Activity{}
+		if err := rows.Scan(&a.ID, &a.BoardID, &a.TaskID, &a.Action, &a.Actor, &a.Changes, &a.CreatedAt); err != nil {
+			return nil, err
+		}
+		entries = append(entries, a)
+	}
+	return entries, rows.Err()
+}
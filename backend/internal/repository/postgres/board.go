@@ -2,8 +2,12 @@ package postgres
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
 )
@@ -16,31 +20,57 @@ func NewBoardRepository(db *pgxpool.Pool) *BoardRepository {
 	return &BoardRepository{db: db}
 }
 
+// hashKey returns the hex SHA-256 of a board key, which is all that is stored
+// of it. Keys are 128 random bits, so an unsalted hash cannot be reversed.
+func hashKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
 func (r *BoardRepository) Create(ctx context.Context, board *domain.Board) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
 	query := `
-		INSERT INTO boards (id, key, title, created_at, expires_at)
-		VALUES ($1, $2, $3, $4, $5)
+		INSERT INTO boards (id, key_hash, title, created_at, updated_at, expires_at, enforce_dependencies, ttl_seconds, sliding_expiry)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
 		RETURNING id
 	`
-	err := r.db.QueryRow(ctx, query,
-		board.ID, board.Key, board.Title, board.CreatedAt, board.ExpiresAt,
+	err = tx.QueryRow(ctx, query,
+		board.ID, hashKey(board.Key), board.Title, board.CreatedAt, board.UpdatedAt, board.ExpiresAt, board.EnforceDependencies,
+		board.TTLSeconds, board.SlidingExpiry,
 	).Scan(&board.ID)
-
 	if err != nil {
 		return err
 	}
-	return nil
+
+	laneQuery := `
+		INSERT INTO board_lanes (id, board_id, name, position)
+		VALUES ($1, $2, $3, $4)
+	`
+	for _, lane := range board.Lanes {
+		lane.BoardID = board.ID
+		if _, err := tx.Exec(ctx, laneQuery, lane.ID, lane.BoardID, lane.Name, lane.Position); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
 }
 
 func (r *BoardRepository) GetByKey(ctx context.Context, key string) (*domain.Board, error) {
 	query := `
-		SELECT id, key, title, created_at, expires_at
+		SELECT id, title, created_at, updated_at, expires_at, enforce_dependencies, ttl_seconds, sliding_expiry
 		FROM boards
-		WHERE key = $1
+		WHERE key_hash = $1
 	`
 	board := &domain.Board{}
-	err := r.db.QueryRow(ctx, query, key).Scan(
-		&board.ID, &board.Key, &board.Title, &board.CreatedAt, &board.ExpiresAt,
+	err := r.db.QueryRow(ctx, query, hashKey(key)).Scan(
+		&board.ID, &board.Title, &board.CreatedAt, &board.UpdatedAt, &board.ExpiresAt, &board.EnforceDependencies,
+		&board.TTLSeconds, &board.SlidingExpiry,
 	)
 	if err != nil {
 		return nil, err
@@ -50,13 +80,14 @@ func (r *BoardRepository) GetByKey(ctx context.Context, key string) (*domain.Boa
 
 func (r *BoardRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Board, error) {
 	query := `
-		SELECT id, key, title, created_at, expires_at
+		SELECT id, title, created_at, updated_at, expires_at, enforce_dependencies, ttl_seconds, sliding_expiry
 		FROM boards
 		WHERE id = $1
 	`
 	board := &domain.Board{}
 	err := r.db.QueryRow(ctx, query, id).Scan(
-		&board.ID, &board.Key, &board.Title, &board.CreatedAt, &board.ExpiresAt,
+		&board.ID, &board.Title, &board.CreatedAt, &board.UpdatedAt, &board.ExpiresAt, &board.EnforceDependencies,
+		&board.TTLSeconds, &board.SlidingExpiry,
 	)
 	if err != nil {
 		return nil, err
@@ -64,10 +95,244 @@ func (r *BoardRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Bo
 	return board, nil
 }
 
+func (r *BoardRepository) Update(ctx context.Context, board *domain.Board) error {
+	query := `
+		UPDATE boards
+		SET title = $1, enforce_dependencies = $2, updated_at = $3, expires_at = $4, ttl_seconds = $5, sliding_expiry = $6
+		WHERE id = $7
+	`
+	_, err := r.db.Exec(ctx, query,
+		board.Title, board.EnforceDependencies, board.UpdatedAt, board.ExpiresAt, board.TTLSeconds, board.SlidingExpiry, board.ID,
+	)
+	return err
+}
+
+func (r *BoardRepository) SlideExpiry(ctx context.Context, key string, now time.Time) error {
+	query := `
+		UPDATE boards
+		SET expires_at = $2 + make_interval(secs => ttl_seconds), updated_at = $2
+		WHERE key_hash = $1 AND sliding_expiry AND expires_at > $2
+		  AND expires_at < $2 + make_interval(secs => ttl_seconds)
+	`
+	_, err := r.db.Exec(ctx, query, hashKey(key), now)
+	return err
+}
+
 func (r *BoardRepository) DeleteByKey(ctx context.Context, key string) error {
-	query := `DELETE FROM boards WHERE key = $1`
-	_, err := r.db.Exec(ctx, query, key)
+	query := `DELETE FROM boards WHERE key_hash = $1`
+	_, err := r.db.Exec(ctx, query, hashKey(key))
+	return err
+}
+
+// expiryBatchSize bounds how many boards DeleteExpired removes per statement,
+// so that a large backlog does not hold locks on every task at once.
+const expiryBatchSize = 500
+
+func (r *BoardRepository) DeleteExpired(ctx context.Context, before time.Time) ([]uuid.UUID, error) {
+	// The advisory lock belongs to the session, so keep one connection for
+	// the whole run.
+	conn, err := r.db.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Release()
+
+	var locked bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext('kanbin.delete_expired_boards'))`).Scan(&locked); err != nil {
+		return nil, err
+	}
+	if !locked {
+		return nil, domain.ErrExpiryInProgress
+	}
+	defer func() {
+		// Unlock even if ctx is done; a connection that cannot be unlocked
+		// must not go back to the pool still holding the lock.
+		unlockCtx := context.WithoutCancel(ctx)
+		if _, err := conn.Exec(unlockCtx, `SELECT pg_advisory_unlock(hashtext('kanbin.delete_expired_boards'))`); err != nil {
+			conn.Hijack().Close(unlockCtx)
+		}
+	}()
+
+	var ids []uuid.UUID
+	for {
+		rows, err := conn.Query(ctx, `
+			DELETE FROM boards
+			WHERE id IN (SELECT id FROM boards WHERE expires_at < $1 LIMIT $2)
+			RETURNING id
+		`, before, expiryBatchSize)
+		if err != nil {
+			return ids, err
+		}
+		n := 0
+		for rows.Next() {
+			var id uuid.UUID
+			if err := rows.Scan(&id); err != nil {
+				rows.Close()
+				return ids, err
+			}
+			ids = append(ids, id)
+			n++
+		}
+		rows.Close()
+		if err := rows.Err(); err != nil {
+			return ids, err
+		}
+		if n < expiryBatchSize {
+			return ids, nil
+		}
+	}
+}
+
+func (r *BoardRepository) ListLanes(ctx context.Context, boardID uuid.UUID) ([]*domain.Lane, error) {
+	query := `
+		SELECT id, board_id, name, position, wip_limit
+		FROM board_lanes
+		WHERE board_id = $1
+		ORDER BY position, name
+	`
+	rows, err := r.db.Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lanes []*domain.Lane
+	for rows.Next() {
+		lane := &domain.Lane{}
+		if err := rows.Scan(&lane.ID, &lane.BoardID, &lane.Name, &lane.Position, &lane.WIPLimit); err != nil {
+			return nil, err
+		}
+		lanes = append(lanes, lane)
+	}
+	return lanes, rows.Err()
+}
+
+func (r *BoardRepository) CreateLane(ctx context.Context, lane *domain.Lane) error {
+	query := `
+		INSERT INTO board_lanes (id, board_id, name, position, wip_limit)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query, lane.ID, lane.BoardID, lane.Name, lane.Position, lane.WIPLimit).Scan(&lane.ID)
+}
+
+func (r *BoardRepository) UpdateLane(ctx context.Context, lane *domain.Lane, oldName domain.TaskStatus, laneIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE board_lanes SET name = $1, wip_limit = $2 WHERE id = $3`
+	if _, err := tx.Exec(ctx, query, lane.Name, lane.WIPLimit, lane.ID); err != nil {
+		return err
+	}
+
+	if lane.Name != oldName {
+		// Tasks reference their lane by name, so carry them over to the new one.
+		query := `
+			UPDATE tasks
+			SET status = $1, updated_at = NOW(), version = version + 1
+			WHERE board_id = $2 AND status = $3
+		`
+		if _, err := tx.Exec(ctx, query, lane.Name, lane.BoardID, oldName); err != nil {
+			return err
+		}
+	}
+
+	if laneIDs != nil {
+		if err := reorderLanes(ctx, tx, lane.BoardID, laneIDs); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *BoardRepository) ReorderLanes(ctx context.Context, boardID uuid.UUID, laneIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := reorderLanes(ctx, tx, boardID, laneIDs); err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
+
+// reorderLanes assigns positions 0..n-1 to laneIDs inside tx.
+func reorderLanes(ctx context.Context, tx pgx.Tx, boardID uuid.UUID, laneIDs []uuid.UUID) error {
+	query := `UPDATE board_lanes SET position = $1 WHERE id = $2 AND board_id = $3`
+	for i, id := range laneIDs {
+		if _, err := tx.Exec(ctx, query, i, id, boardID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *BoardRepository) DeleteLane(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM board_lanes WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
 	return err
 }
 
+func (r *BoardRepository) GetByViewKey(ctx context.Context, key string) (*domain.Board, error) {
+	query := `
+		SELECT b.id, b.title, b.created_at, b.updated_at, b.expires_at, b.enforce_dependencies, b.ttl_seconds, b.sliding_expiry
+		FROM board_view_keys v
+		JOIN boards b ON b.id = v.board_id
+		WHERE v.key_hash = $1
+	`
+	board := &domain.Board{}
+	err := r.db.QueryRow(ctx, query, hashKey(key)).Scan(
+		&board.ID, &board.Title, &board.CreatedAt, &board.UpdatedAt, &board.ExpiresAt, &board.EnforceDependencies,
+		&board.TTLSeconds, &board.SlidingExpiry,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return board, nil
+}
+
+func (r *BoardRepository) CreateViewKey(ctx context.Context, viewKey *domain.ViewKey) error {
+	query := `
+		INSERT INTO board_view_keys (id, board_id, key_hash, key_prefix, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query, viewKey.ID, viewKey.BoardID, hashKey(viewKey.Key), viewKey.Prefix, viewKey.CreatedAt).Scan(&viewKey.ID)
+}
 
+func (r *BoardRepository) ListViewKeys(ctx context.Context, boardID uuid.UUID) ([]*domain.ViewKey, error) {
+	query := `
+		SELECT id, board_id, key_prefix, created_at
+		FROM board_view_keys
+		WHERE board_id = $1
+		ORDER BY created_at, id
+	`
+	rows, err := r.db.Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var viewKeys []*domain.ViewKey
+	for rows.Next() {
+		viewKey := &domain.ViewKey{}
+		if err := rows.Scan(&viewKey.ID, &viewKey.BoardID, &viewKey.Prefix, &viewKey.CreatedAt); err != nil {
+			return nil, err
+		}
+		viewKeys = append(viewKeys, viewKey)
+	}
+	return viewKeys, rows.Err()
+}
+
+func (r *BoardRepository) DeleteViewKey(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM board_view_keys WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+type ChecklistRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewChecklistRepository(db *pgxpool.Pool) *ChecklistRepository {
+	return &ChecklistRepository{db: db}
+}
+
+func (r *ChecklistRepository) Create(ctx context.Context, item *domain.ChecklistItem) error {
+	query := `
+		INSERT INTO task_checklist_items (id, task_id, text, done, position, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		item.ID, item.TaskID, item.Text, item.Done, item.Position, item.CreatedAt, item.UpdatedAt,
+	).Scan(&item.ID)
+}
+
+func (r *ChecklistRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.ChecklistItem, error) {
+	query := `
+		SELECT id, task_id, text, done, position, created_at, updated_at
+		FROM task_checklist_items
+		WHERE id = $1
+	`
+	item := &domain.ChecklistItem{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&item.ID, &item.TaskID, &item.Text, &item.Done, &item.Position, &item.CreatedAt, &item.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+func (r *ChecklistRepository) ListByTaskID(ctx context.Context, taskID uuid.UUID) ([]*domain.ChecklistItem, error) {
+	query := `
+		SELECT id, task_id, text, done, position, created_at, updated_at
+		FROM task_checklist_items
+		WHERE task_id = $1
+		ORDER BY position, created_at
+	`
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []*domain.ChecklistItem
+	for rows.Next() {
+		item := &domain.ChecklistItem{}
+		if err := rows.Scan(
+			&item.ID, &item.TaskID, &item.Text, &item.Done, &item.Position, &item.CreatedAt, &item.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, rows.Err()
+}
+
+func (r *ChecklistRepository) Update(ctx context.Context, item *domain.ChecklistItem) error {
+	query := `
+		UPDATE task_checklist_items
+		SET text = $1, done = $2, updated_at = $3
+		WHERE id = $4
+	`
+	_, err := r.db.Exec(ctx, query, item.Text, item.Done, item.UpdatedAt, item.ID)
+	return err
+}
+
+func (r *ChecklistRepository) Reorder(ctx context.Context, taskID uuid.UUID, itemIDs []uuid.UUID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	query := `UPDATE task_checklist_items SET position = $1 WHERE id = $2 AND task_id = $3`
+	for i, id := range itemIDs {
+		if _, err := tx.Exec(ctx, query, i, id, taskID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (r *ChecklistRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM task_checklist_items WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
+
+func (r *ChecklistRepository) SummarizeByBoardID(ctx context.Context, boardID uuid.UUID) (map[uuid.UUID]domain.ChecklistSummary, error) {
+	query := `
+		SELECT c.task_id, COUNT(*) FILTER (WHERE c.done), COUNT(*)
+		FROM task_checklist_items c
+		JOIN tasks t ON t.id = c.task_id
+		WHERE t.board_id = $1
+		GROUP BY c.task_id
+	`
+	rows, err := r.db.Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	summaries := make(map[uuid.UUID]domain.ChecklistSummary)
+	for rows.Next() {
+		var taskID uuid.UUID
+		var s domain.ChecklistSummary
+		if err := rows.Scan(&taskID, &s.Done, &s.Total); err != nil {
+			return nil, err
+		}
+		summaries[taskID] = s
+	}
+	return summaries, rows.Err()
+}
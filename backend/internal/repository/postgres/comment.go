@@ -0,0 +1,83 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+type CommentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewCommentRepository(db *pgxpool.Pool) *CommentRepository {
+	return &CommentRepository{db: db}
+}
+
+func (r *CommentRepository) Create(ctx context.Context, comment *domain.Comment) error {
+	query := `
+		INSERT INTO task_comments (id, task_id, author, body, created_at)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id
+	`
+	return r.db.QueryRow(ctx, query,
+		comment.ID, comment.TaskID, comment.Author, comment.Body, comment.CreatedAt,
+	).Scan(&comment.ID)
+}
+
+func (r *CommentRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, author, body, created_at
+		FROM task_comments
+		WHERE id = $1
+	`
+	comment := &domain.Comment{}
+	err := r.db.QueryRow(ctx, query, id).Scan(
+		&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &comment.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+func (r *CommentRepository) ListByTaskID(ctx context.Context, taskID uuid.UUID) ([]*domain.Comment, error) {
+	query := `
+		SELECT id, task_id, author, body, created_at
+		FROM task_comments
+		WHERE task_id = $1
+		ORDER BY created_at, id
+	`
+	rows, err := r.db.Query(ctx, query, taskID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var comments []*domain.Comment
+	for rows.Next() {
+		comment := &domain.Comment{}
+		if err := rows.Scan(
+			&comment.ID, &comment.TaskID, &comment.Author, &comment.Body, &comment.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		comments = append(comments, comment)
+	}
+	return comments, rows.Err()
+}
+
+func (r *CommentRepository) CountByTaskID(ctx context.Context, taskID uuid.UUID) (int, error) {
+	query := `SELECT COUNT(*) FROM task_comments WHERE task_id = $1`
+	var count int
+	err := r.db.QueryRow(ctx, query, taskID).Scan(&count)
+	return count, err
+}
+
+func (r *CommentRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `DELETE FROM task_comments WHERE id = $1`
+	_, err := r.db.Exec(ctx, query, id)
+	return err
+}
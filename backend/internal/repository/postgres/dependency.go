@@ -0,0 +1,90 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+type DependencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewDependencyRepository(db *pgxpool.Pool) *DependencyRepository {
+	return &DependencyRepository{db: db}
+}
+
+// Create takes the board's lock before reading its edges, so two requests
+// adding opposite edges cannot both pass the cycle check.
+func (r *DependencyRepository) Create(ctx context.Context, boardID uuid.UUID, dep *domain.Dependency) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBoardTasks(ctx, tx, boardID); err != nil {
+		return err
+	}
+	deps, err := listDependencies(ctx, tx, boardID)
+	if err != nil {
+		return err
+	}
+	// Adding task → blocker closes a cycle if blocker already depends on task.
+	if domain.DependsOn(deps, dep.BlockedByID, dep.TaskID) {
+		return domain.ErrDependencyCycle
+	}
+
+	query := `
+		INSERT INTO task_dependencies (task_id, blocked_by_id)
+		VALUES ($1, $2)
+		ON CONFLICT DO NOTHING
+	`
+	if _, err := tx.Exec(ctx, query, dep.TaskID, dep.BlockedByID); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *DependencyRepository) Delete(ctx context.Context, taskID, blockedByID uuid.UUID) error {
+	query := `DELETE FROM task_dependencies WHERE task_id = $1 AND blocked_by_id = $2`
+	_, err := r.db.Exec(ctx, query, taskID, blockedByID)
+	return err
+}
+
+func (r *DependencyRepository) ListByBoardID(ctx context.Context, boardID uuid.UUID) ([]*domain.Dependency, error) {
+	return listDependencies(ctx, r.db, boardID)
+}
+
+// querier is satisfied by both the pool and a transaction.
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+}
+
+// listDependencies reads the board's edges through the pool or a transaction.
+func listDependencies(ctx context.Context, q querier, boardID uuid.UUID) ([]*domain.Dependency, error) {
+	query := `
+		SELECT d.task_id, d.blocked_by_id
+		FROM task_dependencies d
+		JOIN tasks t ON t.id = d.task_id
+		WHERE t.board_id = $1
+	`
+	rows, err := q.Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var deps []*domain.Dependency
+	for rows.Next() {
+		dep := &domain.Dependency{}
+		if err := rows.Scan(&dep.TaskID, &dep.BlockedByID); err != nil {
+			return nil, err
+		}
+		deps = append(deps, dep)
+	}
+	return deps, rows.Err()
+}
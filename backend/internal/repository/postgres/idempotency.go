@@ -0,0 +1,74 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+)
+
+type IdempotencyRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewIdempotencyRepository(db *pgxpool.Pool) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+func (r *IdempotencyRepository) Claim(ctx context.Context, key, fingerprint string, notBefore, leaseBefore time.Time) (uuid.UUID, *domain.IdempotentResponse, error) {
+	token := uuid.New()
+	// The claim can lose a race with a Release of the existing record, in
+	// which case the key is free again on the next attempt.
+	for attempt := 0; ; attempt++ {
+		tag, err := r.db.Exec(ctx, `
+			INSERT INTO idempotency_keys (key_hash, fingerprint, claim_token)
+			VALUES ($1, $2, $5)
+			ON CONFLICT (key_hash) DO UPDATE
+			SET fingerprint = EXCLUDED.fingerprint, claim_token = EXCLUDED.claim_token,
+				status = 0, headers = '{}', body = NULL, created_at = NOW()
+			WHERE idempotency_keys.created_at < $3
+				OR (idempotency_keys.status = 0 AND idempotency_keys.created_at < $4)
+		`, key, fingerprint, notBefore, leaseBefore, token)
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		if tag.RowsAffected() == 1 {
+			return token, nil, nil
+		}
+
+		resp := &domain.IdempotentResponse{}
+		err = r.db.QueryRow(ctx, `
+			SELECT fingerprint, status, headers, body FROM idempotency_keys WHERE key_hash = $1
+		`, key).Scan(&resp.Fingerprint, &resp.Status, &resp.Header, &resp.Body)
+		if errors.Is(err, pgx.ErrNoRows) && attempt == 0 {
+			continue
+		}
+		if err != nil {
+			return uuid.Nil, nil, err
+		}
+		return uuid.Nil, resp, nil
+	}
+}
+
+func (r *IdempotencyRepository) Complete(ctx context.Context, key string, token uuid.UUID, resp *domain.IdempotentResponse) error {
+	query := `UPDATE idempotency_keys SET status = $1, headers = $2, body = $3 WHERE key_hash = $4 AND claim_token = $5`
+	_, err := r.db.Exec(ctx, query, resp.Status, resp.Header, resp.Body, key, token)
+	return err
+}
+
+func (r *IdempotencyRepository) Release(ctx context.Context, key string, token uuid.UUID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE key_hash = $1 AND claim_token = $2`, key, token)
+	return err
+}
+
+func (r *IdempotencyRepository) DeleteBefore(ctx context.Context, t time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM idempotency_keys WHERE created_at < $1`, t)
+	if err != nil {
+		return 0, err
+	}
+	return tag.RowsAffected(), nil
+}
@@ -2,12 +2,40 @@ package postgres
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"html"
+	"slices"
+	"strings"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/zeeshanejaz/kanbin/backend/internal/domain"
+	"github.com/zeeshanejaz/kanbin/backend/internal/rank"
 )
 
+// taskColumns is the select list shared by every task query. Labels are
+// aggregated from task_labels so a task is always read in a single row, and
+// the position is the number of unarchived lane tasks ranked before it.
+const taskColumns = `
+	t.id, t.board_id, t.title, t.description, t.status, t.rank,
+	(SELECT COUNT(*) FROM tasks p
+	 WHERE p.board_id = t.board_id AND p.status = t.status AND p.archived_at IS NULL AND p.rank < t.rank),
+	ARRAY(SELECT tl.label FROM task_labels tl WHERE tl.task_id = t.id ORDER BY tl.label),
+	t.assignee, t.due_at, t.priority, t.archived_at, t.created_at, t.updated_at, t.version
+`
+
+// priorityRankSQL maps tasks.priority to the numeric rank of domain.TaskPriority.
+const priorityRankSQL = `
+	CASE t.priority
+		WHEN 'URGENT' THEN 4
+		WHEN 'HIGH' THEN 3
+		WHEN 'MEDIUM' THEN 2
+		WHEN 'LOW' THEN 1
+		ELSE 0
+	END`
+
 type TaskRepository struct {
 	db *pgxpool.Pool
 }
@@ -16,43 +44,240 @@ func NewTaskRepository(db *pgxpool.Pool) *TaskRepository {
 	return &TaskRepository{db: db}
 }
 
-func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
-	query := `
-		INSERT INTO tasks (id, board_id, title, description, status, position, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id
-	`
-	err := r.db.QueryRow(ctx, query,
-		task.ID, task.BoardID, task.Title, task.Description, task.Status, task.Position, task.CreatedAt, task.UpdatedAt,
-	).Scan(&task.ID)
+// taskDest returns scan destinations matching taskColumns.
+func taskDest(task *domain.Task) []interface{} {
+	return []interface{}{
+		&task.ID, &task.BoardID, &task.Title, &task.Description, &task.Status, &task.Rank, &task.Position,
+		&task.Labels, &task.Assignee, &task.DueAt, &task.Priority, &task.ArchivedAt, &task.CreatedAt, &task.UpdatedAt, &task.Version,
+	}
+}
+
+func scanTask(row pgx.Row) (*domain.Task, error) {
+	task := &domain.Task{}
+	if err := row.Scan(taskDest(task)...); err != nil {
+		return nil, err
+	}
+	return task, nil
+}
 
+// replaceLabels rewrites the full label set of a task inside tx.
+func replaceLabels(ctx context.Context, tx pgx.Tx, taskID uuid.UUID, labels []string) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM task_labels WHERE task_id = $1`, taskID); err != nil {
+		return err
+	}
+	for _, label := range labels {
+		if _, err := tx.Exec(ctx, `INSERT INTO task_labels (task_id, label) VALUES ($1, $2)`, taskID, label); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkWIPLimit returns domain.ErrWIPLimitReached if the task's lane already
+// holds its WIP limit of other unarchived tasks. The lane row is locked first so that
+// concurrent moves into the same lane are counted one after another.
+func checkWIPLimit(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+	var limit int
+	err := tx.QueryRow(ctx, `
+		SELECT wip_limit FROM board_lanes
+		WHERE board_id = $1 AND name = $2
+		FOR UPDATE
+	`, task.BoardID, task.Status).Scan(&limit)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if limit == 0 {
+		return nil
+	}
+
+	var count int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM tasks
+		WHERE board_id = $1 AND status = $2 AND id <> $3 AND archived_at IS NULL
+	`, task.BoardID, task.Status, task.ID).Scan(&count)
+	if err != nil {
+		return err
+	}
+	if count >= limit {
+		return domain.ErrWIPLimitReached
+	}
+	return nil
+}
+
+// lockBoardTasks takes the board's row lock. Every write that changes which
+// tasks are in a lane or their order holds it, so new ranks are computed
+// against a lane no other transaction is changing.
+func lockBoardTasks(ctx context.Context, tx pgx.Tx, boardID uuid.UUID) error {
+	_, err := tx.Exec(ctx, `SELECT 1 FROM boards WHERE id = $1 FOR UPDATE`, boardID)
 	return err
 }
 
-func (r *TaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
+// laneEntry is a task's place in its lane.
+type laneEntry struct {
+	id   uuid.UUID
+	rank string
+}
+
+// laneOrder returns the unarchived tasks in a lane in rank order, leaving out
+// exclude.
+func laneOrder(ctx context.Context, tx pgx.Tx, boardID uuid.UUID, status domain.TaskStatus, exclude uuid.UUID) ([]laneEntry, error) {
+	rows, err := tx.Query(ctx, `
+		SELECT id, rank FROM tasks
+		WHERE board_id = $1 AND status = $2 AND archived_at IS NULL AND id <> $3
+		ORDER BY rank, id
+	`, boardID, status, exclude)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var lane []laneEntry
+	for rows.Next() {
+		var e laneEntry
+		if err := rows.Scan(&e.id, &e.rank); err != nil {
+			return nil, err
+		}
+		lane = append(lane, e)
+	}
+	return lane, rows.Err()
+}
+
+// rankAt returns a rank that places a task at index i of lane, which must
+// not contain the task itself.
+func rankAt(lane []laneEntry, i int) string {
+	var before, after string
+	if i > 0 {
+		before = lane[i-1].rank
+	}
+	if i < len(lane) {
+		after = lane[i].rank
+	}
+	return rank.Between(before, after)
+}
+
+func (r *TaskRepository) Create(ctx context.Context, task *domain.Task) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := insertTask(ctx, tx, task); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func insertTask(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+	if err := lockBoardTasks(ctx, tx, task.BoardID); err != nil {
+		return err
+	}
+	if err := checkWIPLimit(ctx, tx, task); err != nil {
+		return err
+	}
+	lane, err := laneOrder(ctx, tx, task.BoardID, task.Status, task.ID)
+	if err != nil {
+		return err
+	}
+	task.Position = len(lane)
+	task.Rank = rankAt(lane, task.Position)
+
 	query := `
-		SELECT id, board_id, title, description, status, position, created_at, updated_at
-		FROM tasks
-		WHERE id = $1
+		INSERT INTO tasks (id, board_id, title, description, status, rank, assignee, due_at, priority, archived_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		RETURNING id, version
 	`
-	task := &domain.Task{}
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&task.ID, &task.BoardID, &task.Title, &task.Description, &task.Status, &task.Position, &task.CreatedAt, &task.UpdatedAt,
-	)
+	err = tx.QueryRow(ctx, query,
+		task.ID, task.BoardID, task.Title, task.Description, task.Status, task.Rank, task.Assignee,
+		task.DueAt, task.Priority, task.ArchivedAt, task.CreatedAt, task.UpdatedAt,
+	).Scan(&task.ID, &task.Version)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	return task, nil
+
+	return replaceLabels(ctx, tx, task.ID, task.Labels)
 }
 
-func (r *TaskRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([]*domain.Task, error) {
+func (r *TaskRepository) GetByID(ctx context.Context, id uuid.UUID) (*domain.Task, error) {
 	query := `
-		SELECT id, board_id, title, description, status, position, created_at, updated_at
-		FROM tasks
-		WHERE board_id = $1
-		ORDER BY status, position
+		SELECT ` + taskColumns + `
+		FROM tasks t
+		WHERE t.id = $1
 	`
-	rows, err := r.db.Query(ctx, query, boardID)
+	return scanTask(r.db.QueryRow(ctx, query, id))
+}
+
+func (r *TaskRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID, filter domain.TaskFilter) ([]*domain.Task, error) {
+	conds := []string{"t.board_id = $1"}
+	args := []interface{}{boardID}
+
+	if !filter.IncludeArchived {
+		conds = append(conds, "t.archived_at IS NULL")
+	}
+	if len(filter.Labels) > 0 {
+		args = append(args, filter.Labels)
+		conds = append(conds, fmt.Sprintf(`
+			(SELECT COUNT(DISTINCT fl.label) FROM task_labels fl
+			 WHERE fl.task_id = t.id AND fl.label = ANY($%d)) = cardinality($%d::text[])`,
+			len(args), len(args)))
+	}
+	if filter.Assignee != nil {
+		args = append(args, *filter.Assignee)
+		conds = append(conds, fmt.Sprintf("t.assignee = $%d", len(args)))
+	}
+	if filter.Overdue {
+		conds = append(conds, `t.due_at < NOW() AND t.status IS DISTINCT FROM (
+			SELECT fl.name FROM board_lanes fl WHERE fl.board_id = t.board_id
+			ORDER BY fl.position DESC LIMIT 1)`)
+	}
+
+	if len(filter.Statuses) > 0 {
+		args = append(args, filter.Statuses)
+		conds = append(conds, fmt.Sprintf("t.status = ANY($%d)", len(args)))
+	}
+
+	// Order by the board's lane order by default; tasks whose status has no
+	// matching lane sort last. Every key ascends so that a page can resume
+	// after a cursor with a single row comparison.
+	keys := []string{fmt.Sprintf("COALESCE(l.position, %d)", domain.UnlanedPosition), "t.status", "t.rank", "t.id"}
+	var cursor []interface{}
+	if c := filter.After; c != nil {
+		cursor = []interface{}{c.LanePosition, c.Status, c.Rank, c.ID}
+	}
+	if filter.Sort == domain.SortByPriority {
+		keys = append([]string{"-" + priorityRankSQL, "COALESCE(t.due_at, 'infinity')"}, keys...)
+		if c := filter.After; c != nil {
+			cursor = append([]interface{}{-c.PriorityRank, c.DueAt}, cursor...)
+		}
+	}
+	if cursor != nil {
+		params := make([]string, len(cursor))
+		for i, v := range cursor {
+			args = append(args, v)
+			params[i] = fmt.Sprintf("$%d", len(args))
+		}
+		if filter.Sort == domain.SortByPriority {
+			// A task without a due date sorts as if due at infinity.
+			params[1] = fmt.Sprintf("COALESCE(%s::timestamptz, 'infinity')", params[1])
+		}
+		conds = append(conds, fmt.Sprintf("(%s) > (%s)", strings.Join(keys, ", "), strings.Join(params, ", ")))
+	}
+
+	query := `
+		SELECT ` + taskColumns + `
+		FROM tasks t
+		LEFT JOIN board_lanes l ON l.board_id = t.board_id AND l.name = t.status
+		WHERE ` + strings.Join(conds, " AND ") + `
+		ORDER BY ` + strings.Join(keys, ", ")
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+
+	rows, err := r.db.Query(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -60,10 +285,8 @@ func (r *TaskRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([
 
 	var tasks []*domain.Task
 	for rows.Next() {
-		task := &domain.Task{}
-		if err := rows.Scan(
-			&task.ID, &task.BoardID, &task.Title, &task.Description, &task.Status, &task.Position, &task.CreatedAt, &task.UpdatedAt,
-		); err != nil {
+		task, err := scanTask(rows)
+		if err != nil {
 			return nil, err
 		}
 		tasks = append(tasks, task)
@@ -72,15 +295,215 @@ func (r *TaskRepository) GetByBoardID(ctx context.Context, boardID uuid.UUID) ([
 }
 
 func (r *TaskRepository) Update(ctx context.Context, task *domain.Task) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := updateTask(ctx, tx, task); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaskRepository) Move(ctx context.Context, task *domain.Task, neighbour domain.Neighbour) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := moveTask(ctx, tx, task, neighbour); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// moveTask resolves the neighbour to an index in the task's new lane and
+// stores the task there. The board lock is taken first so the index cannot
+// go stale before updateTask uses it.
+func moveTask(ctx context.Context, tx pgx.Tx, task *domain.Task, neighbour domain.Neighbour) error {
+	if err := lockBoardTasks(ctx, tx, task.BoardID); err != nil {
+		return err
+	}
+	lane, err := laneOrder(ctx, tx, task.BoardID, task.Status, task.ID)
+	if err != nil {
+		return err
+	}
+	index := slices.IndexFunc(lane, func(e laneEntry) bool { return e.id == neighbour.ID })
+	if index < 0 {
+		return domain.ErrNeighbourNotInLane
+	}
+	if neighbour.After {
+		index++
+	}
+	task.Position = index
+	return updateTask(ctx, tx, task)
+}
+
+func updateTask(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+	if err := lockBoardTasks(ctx, tx, task.BoardID); err != nil {
+		return err
+	}
+
+	// The row lock makes the version check and the write one compare-and-swap.
+	var current domain.TaskStatus
+	var currentRank string
+	var version int
+	var archived bool
+	err := tx.QueryRow(ctx, `
+		SELECT status, rank, archived_at IS NOT NULL, version FROM tasks WHERE id = $1 FOR UPDATE
+	`, task.ID).Scan(&current, &currentRank, &archived, &version)
+	if err != nil {
+		return err
+	}
+	if version != task.Version {
+		return domain.ErrVersionConflict
+	}
+
+	// Only a task entering a lane, by moving or by being restored from the
+	// archive, is subject to the lane's WIP limit, so edits to tasks in an
+	// over-limit lane still go through.
+	if task.ArchivedAt == nil && (current != task.Status || archived) {
+		if err := checkWIPLimit(ctx, tx, task); err != nil {
+			return err
+		}
+	}
+
+	// A task entering a lane or moving within it gets a rank between its new
+	// neighbours; no other task is touched.
+	task.Rank = currentRank
+	if task.ArchivedAt == nil {
+		lane, err := laneOrder(ctx, tx, task.BoardID, task.Status, task.ID)
+		if err != nil {
+			return err
+		}
+		index := slices.IndexFunc(lane, func(e laneEntry) bool { return e.rank > currentRank })
+		if index < 0 {
+			index = len(lane)
+		}
+		if current != task.Status || archived || index != task.Position {
+			task.Position = min(max(task.Position, 0), len(lane))
+			task.Rank = rankAt(lane, task.Position)
+		}
+	}
+
 	query := `
 		UPDATE tasks
-		SET title = $1, description = $2, status = $3, position = $4, updated_at = $5
-		WHERE id = $6
+		SET title = $1, description = $2, status = $3, rank = $4, assignee = $5,
+		    due_at = $6, priority = $7, archived_at = $8, updated_at = $9, version = version + 1
+		WHERE id = $10
+		RETURNING version
 	`
-	_, err := r.db.Exec(ctx, query,
-		task.Title, task.Description, task.Status, task.Position, task.UpdatedAt, task.ID,
-	)
-	return err
+	err = tx.QueryRow(ctx, query,
+		task.Title, task.Description, task.Status, task.Rank, task.Assignee,
+		task.DueAt, task.Priority, task.ArchivedAt, task.UpdatedAt, task.ID,
+	).Scan(&task.Version)
+	if err != nil {
+		return err
+	}
+
+	return replaceLabels(ctx, tx, task.ID, task.Labels)
+}
+
+func (r *TaskRepository) ApplyBatch(ctx context.Context, writes []domain.TaskWrite) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	versions := make(map[uuid.UUID]int)
+	for i, w := range writes {
+		write := updateTask
+		if w.Create {
+			write = insertTask
+		} else if v, ok := versions[w.Task.ID]; ok {
+			w.Task.Version = v
+		}
+		if w.Neighbour != nil {
+			neighbour := *w.Neighbour
+			write = func(ctx context.Context, tx pgx.Tx, task *domain.Task) error {
+				return moveTask(ctx, tx, task, neighbour)
+			}
+		}
+		if err := write(ctx, tx, w.Task); err != nil {
+			return &domain.BatchError{Index: i, Err: err}
+		}
+		versions[w.Task.ID] = w.Task.Version
+	}
+	return tx.Commit(ctx)
+}
+
+func (r *TaskRepository) RebalanceRanks(ctx context.Context, maxLength int) ([]uuid.UUID, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT DISTINCT board_id, status FROM tasks
+		WHERE archived_at IS NULL AND length(rank) > $1
+	`, maxLength)
+	if err != nil {
+		return nil, err
+	}
+	type laneKey struct {
+		boardID uuid.UUID
+		status  domain.TaskStatus
+	}
+	var lanes []laneKey
+	for rows.Next() {
+		var l laneKey
+		if err := rows.Scan(&l.boardID, &l.status); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		lanes = append(lanes, l)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	var boards []uuid.UUID
+	for _, l := range lanes {
+		if err := r.respreadLane(ctx, l.boardID, l.status); err != nil {
+			return boards, err
+		}
+		if !slices.Contains(boards, l.boardID) {
+			boards = append(boards, l.boardID)
+		}
+	}
+	return boards, nil
+}
+
+// respreadLane gives the unarchived tasks of a lane evenly spaced short
+// ranks, keeping their order. Each task's version and updated_at are bumped
+// so that clients holding the old rank see the task as changed.
+func (r *TaskRepository) respreadLane(ctx context.Context, boardID uuid.UUID, status domain.TaskStatus) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+
+	if err := lockBoardTasks(ctx, tx, boardID); err != nil {
+		return err
+	}
+	lane, err := laneOrder(ctx, tx, boardID, status, uuid.Nil)
+	if err != nil {
+		return err
+	}
+	ids := make([]uuid.UUID, len(lane))
+	for i, e := range lane {
+		ids[i] = e.id
+	}
+	_, err = tx.Exec(ctx, `
+		UPDATE tasks t SET rank = v.rank, updated_at = NOW(), version = t.version + 1
+		FROM unnest($1::uuid[], $2::text[]) AS v(id, rank)
+		WHERE t.id = v.id
+	`, ids, rank.Spread(len(lane)))
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
 }
 
 func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
@@ -90,8 +513,85 @@ func (r *TaskRepository) Delete(ctx context.Context, id uuid.UUID) error {
 }
 
 func (r *TaskRepository) CountByBoardID(ctx context.Context, boardID uuid.UUID) (int, error) {
-	query := `SELECT COUNT(*) FROM tasks WHERE board_id = $1`
+	query := `SELECT COUNT(*) FROM tasks WHERE board_id = $1 AND archived_at IS NULL`
 	var count int
 	err := r.db.QueryRow(ctx, query, boardID).Scan(&count)
 	return count, err
 }
+
+func (r *TaskRepository) CountByStatus(ctx context.Context, boardID uuid.UUID, status domain.TaskStatus) (int, error) {
+	query := `SELECT COUNT(*) FROM tasks WHERE board_id = $1 AND status = $2`
+	var count int
+	err := r.db.QueryRow(ctx, query, boardID, status).Scan(&count)
+	return count, err
+}
+
+// Markers passed to ts_headline. The API strips C0 control characters from
+// task titles and descriptions, so these only ever come from ts_headline and
+// can be swapped for <mark> tags after escaping the rest of the snippet.
+const (
+	headlineStart = "\x02"
+	headlineStop  = "\x03"
+)
+
+func (r *TaskRepository) Search(ctx context.Context, boardID uuid.UUID, terms string, limit int) ([]*domain.TaskSearchResult, error) {
+	query := `
+		SELECT ` + taskColumns + `,
+			ts_rank(t.search_vector, q) AS score,
+			ts_headline('english', t.title || E'\n' || t.description, q,
+				'StartSel=` + headlineStart + `, StopSel=` + headlineStop + `, MaxFragments=2, MaxWords=25, MinWords=8')
+		FROM tasks t, websearch_to_tsquery('english', $2) q
+		WHERE t.board_id = $1 AND t.archived_at IS NULL AND t.search_vector @@ q
+		ORDER BY score DESC, t.updated_at DESC
+		LIMIT $3
+	`
+	rows, err := r.db.Query(ctx, query, boardID, terms, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*domain.TaskSearchResult
+	for rows.Next() {
+		result := &domain.TaskSearchResult{Task: &domain.Task{}}
+		dest := append(taskDest(result.Task), &result.Score, &result.Snippet)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
+		}
+		result.Snippet = highlight(result.Snippet)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// highlight escapes a ts_headline snippet for HTML and turns the headline
+// markers into <mark> tags.
+func highlight(snippet string) string {
+	escaped := html.EscapeString(snippet)
+	escaped = strings.ReplaceAll(escaped, headlineStart, "<mark>")
+	return strings.ReplaceAll(escaped, headlineStop, "</mark>")
+}
+
+func (r *TaskRepository) ListAssignees(ctx context.Context, boardID uuid.UUID) ([]string, error) {
+	query := `
+		SELECT DISTINCT assignee
+		FROM tasks
+		WHERE board_id = $1 AND assignee <> '' AND archived_at IS NULL
+		ORDER BY assignee
+	`
+	rows, err := r.db.Query(ctx, query, boardID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var assignees []string
+	for rows.Next() {
+		var a string
+		if err := rows.Scan(&a); err != nil {
+			return nil, err
+		}
+		assignees = append(assignees, a)
+	}
+	return assignees, rows.Err()
+}
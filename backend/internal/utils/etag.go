@@ -4,12 +4,14 @@ import (
 	"crypto/sha256"
 	"encoding/hex"
 	"fmt"
+	"strings"
 	"time"
 )
 
 // GenerateETag creates a consistent hash from board and task update timestamps
-// This allows for conditional GET requests to reduce bandwidth
-func GenerateETag(boardUpdatedAt time.Time, tasksUpdatedAt []time.Time) string {
+// This allows for conditional GET requests to reduce bandwidth. Parts holds
+// anything else the response depends on, such as its query parameters.
+func GenerateETag(boardUpdatedAt time.Time, tasksUpdatedAt []time.Time, parts ...string) string {
 	hasher := sha256.New()
 
 	// Include board updated time
@@ -20,6 +22,12 @@ func GenerateETag(boardUpdatedAt time.Time, tasksUpdatedAt []time.Time) string {
 		hasher.Write([]byte(taskTime.Format(time.RFC3339Nano)))
 	}
 
+	// Separate parts so that moving text between them changes the hash
+	for _, part := range parts {
+		hasher.Write([]byte{0})
+		hasher.Write([]byte(part))
+	}
+
 	hash := hasher.Sum(nil)
 	return fmt.Sprintf(`"%s"`, hex.EncodeToString(hash)[:16]) // First 16 chars, wrapped in quotes
 }
@@ -31,3 +39,21 @@ func ParseETag(etag string) string {
 	}
 	return etag
 }
+
+// VersionETag returns the strong ETag for a resource at the given version.
+func VersionETag(version int) string {
+	return fmt.Sprintf(`"v%d"`, version)
+}
+
+// MatchETag reports whether an If-Match header value matches etag. The header
+// may list several ETags separated by commas or be "*". Weak ETags never
+// match, as If-Match requires strong comparison.
+func MatchETag(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
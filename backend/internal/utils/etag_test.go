@@ -42,6 +42,18 @@ func TestGenerateETag(t *testing.T) {
 		}
 	})
 
+	t.Run("different hash for different parts", func(t *testing.T) {
+		tasks := []time.Time{now}
+
+		etag1 := GenerateETag(now, tasks, "limit=1", "")
+		etag2 := GenerateETag(now, tasks, "limit=2", "")
+		etag3 := GenerateETag(now, tasks, "limit=1", "cursor")
+
+		if etag1 == etag2 || etag1 == etag3 {
+			t.Error("Expected different ETags for different parts")
+		}
+	})
+
 	t.Run("etag is quoted", func(t *testing.T) {
 		etag := GenerateETag(now, []time.Time{})
 
@@ -77,3 +89,32 @@ func TestParseETag(t *testing.T) {
 		}
 	})
 }
+
+func TestVersionETag(t *testing.T) {
+	if VersionETag(3) == VersionETag(4) {
+		t.Error("Expected different ETags for different versions")
+	}
+	if etag := VersionETag(3); etag != `"v3"` {
+		t.Errorf("Expected quoted version ETag, got %s", etag)
+	}
+}
+
+func TestMatchETag(t *testing.T) {
+	etag := VersionETag(2)
+	cases := []struct {
+		header string
+		want   bool
+	}{
+		{`"v2"`, true},
+		{`"v1", "v2"`, true},
+		{"*", true},
+		{`"v1"`, false},
+		{`W/"v2"`, false},
+		{"v2", false},
+	}
+	for _, c := range cases {
+		if got := MatchETag(c.header, etag); got != c.want {
+			t.Errorf("MatchETag(%q): expected %v, got %v", c.header, c.want, got)
+		}
+	}
+}
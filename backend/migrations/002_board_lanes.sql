@@ -0,0 +1,22 @@
+-- +goose Up
+-- Board-owned, ordered lanes. tasks.status holds the name of the lane a task is in.
+
+CREATE TABLE board_lanes (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    position INTEGER NOT NULL DEFAULT 0,
+    UNIQUE (board_id, name)
+);
+
+CREATE INDEX idx_board_lanes_board_id ON board_lanes (board_id);
+
+-- Give existing boards the lanes they implicitly had before.
+INSERT INTO board_lanes (board_id, name, position)
+SELECT b.id, d.name, d.position
+FROM boards b
+CROSS JOIN (VALUES ('TODO', 0), ('IN_PROGRESS', 1), ('DONE', 2)) AS d(name, position);
+
+-- +goose Down
+
+DROP TABLE board_lanes;
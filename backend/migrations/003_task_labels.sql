@@ -0,0 +1,14 @@
+-- +goose Up
+-- Free-form labels attached to tasks, used for grouping and filtering.
+
+CREATE TABLE task_labels (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    label VARCHAR(50) NOT NULL,
+    PRIMARY KEY (task_id, label)
+);
+
+CREATE INDEX idx_task_labels_label ON task_labels (label);
+
+-- +goose Down
+
+DROP TABLE task_labels;
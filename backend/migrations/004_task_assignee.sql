@@ -0,0 +1,11 @@
+-- +goose Up
+-- Free-form assignee on each task; an empty string means unassigned.
+
+ALTER TABLE tasks ADD COLUMN assignee VARCHAR(100) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_board_assignee ON tasks (board_id, assignee);
+
+-- +goose Down
+
+DROP INDEX idx_tasks_board_assignee;
+ALTER TABLE tasks DROP COLUMN assignee;
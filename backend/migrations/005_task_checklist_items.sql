@@ -0,0 +1,18 @@
+-- +goose Up
+-- Ordered checklist items (subtasks) belonging to a task.
+
+CREATE TABLE task_checklist_items (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    text VARCHAR(500) NOT NULL,
+    done BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_task_checklist_items_task_id ON task_checklist_items (task_id);
+
+-- +goose Down
+
+DROP TABLE task_checklist_items;
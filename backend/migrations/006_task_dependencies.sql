@@ -0,0 +1,19 @@
+-- +goose Up
+-- "Blocked by" edges between tasks on the same board, plus the per-board
+-- switch that refuses finishing a task while its blockers are still open.
+
+CREATE TABLE task_dependencies (
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    blocked_by_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    PRIMARY KEY (task_id, blocked_by_id),
+    CHECK (task_id <> blocked_by_id)
+);
+
+CREATE INDEX idx_task_dependencies_blocked_by_id ON task_dependencies (blocked_by_id);
+
+ALTER TABLE boards ADD COLUMN enforce_dependencies BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+
+ALTER TABLE boards DROP COLUMN enforce_dependencies;
+DROP TABLE task_dependencies;
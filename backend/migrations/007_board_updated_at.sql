@@ -0,0 +1,10 @@
+-- +goose Up
+-- Track board-level changes (title, settings, lanes) so board ETags change with them.
+
+ALTER TABLE boards ADD COLUMN updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL;
+
+UPDATE boards SET updated_at = created_at;
+
+-- +goose Down
+
+ALTER TABLE boards DROP COLUMN updated_at;
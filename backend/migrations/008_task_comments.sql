@@ -0,0 +1,16 @@
+-- +goose Up
+-- Append-only comment threads on tasks.
+
+CREATE TABLE task_comments (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    task_id UUID NOT NULL REFERENCES tasks(id) ON DELETE CASCADE,
+    author VARCHAR(100) NOT NULL,
+    body TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_task_comments_task_id ON task_comments (task_id, created_at);
+
+-- +goose Down
+
+DROP TABLE task_comments;
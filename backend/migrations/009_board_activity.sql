@@ -0,0 +1,19 @@
+-- +goose Up
+-- Audit trail of task changes per board. task_id is deliberately not a
+-- foreign key so entries survive the task being deleted.
+
+CREATE TABLE board_activity (
+    id BIGSERIAL PRIMARY KEY,
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    task_id UUID NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    actor VARCHAR(100) NOT NULL DEFAULT '',
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_board_activity_board_id ON board_activity (board_id, id DESC);
+
+-- +goose Down
+
+DROP TABLE board_activity;
@@ -0,0 +1,13 @@
+-- +goose Up
+-- Optional due date and priority on tasks.
+
+ALTER TABLE tasks ADD COLUMN due_at TIMESTAMP WITH TIME ZONE;
+ALTER TABLE tasks ADD COLUMN priority VARCHAR(10) NOT NULL DEFAULT '';
+
+CREATE INDEX idx_tasks_board_due_at ON tasks (board_id, due_at) WHERE due_at IS NOT NULL;
+
+-- +goose Down
+
+DROP INDEX idx_tasks_board_due_at;
+ALTER TABLE tasks DROP COLUMN priority;
+ALTER TABLE tasks DROP COLUMN due_at;
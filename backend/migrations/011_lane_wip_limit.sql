@@ -0,0 +1,8 @@
+-- +goose Up
+-- Per-lane work-in-progress limit. Zero means the lane is unlimited.
+
+ALTER TABLE board_lanes ADD COLUMN wip_limit INTEGER NOT NULL DEFAULT 0 CHECK (wip_limit >= 0);
+
+-- +goose Down
+
+ALTER TABLE board_lanes DROP COLUMN wip_limit;
@@ -0,0 +1,11 @@
+-- +goose Up
+-- Soft deletion: archived tasks keep their row and are filtered out of board reads.
+
+ALTER TABLE tasks ADD COLUMN archived_at TIMESTAMP WITH TIME ZONE;
+
+CREATE INDEX idx_tasks_board_active ON tasks (board_id) WHERE archived_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX idx_tasks_board_active;
+ALTER TABLE tasks DROP COLUMN archived_at;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Full-text search over task titles (weight A) and descriptions (weight B).
+
+ALTER TABLE tasks ADD COLUMN search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(description, '')), 'B')
+    ) STORED;
+
+CREATE INDEX idx_tasks_search_vector ON tasks USING GIN (search_vector);
+
+-- +goose Down
+
+DROP INDEX idx_tasks_search_vector;
+ALTER TABLE tasks DROP COLUMN search_vector;
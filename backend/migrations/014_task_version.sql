@@ -0,0 +1,8 @@
+-- +goose Up
+-- Optimistic concurrency: every stored change to a task increments its version.
+
+ALTER TABLE tasks ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- +goose Down
+
+ALTER TABLE tasks DROP COLUMN version;
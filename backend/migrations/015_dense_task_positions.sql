@@ -0,0 +1,18 @@
+-- +goose Up
+-- Positions become each unarchived task's zero-based index within its lane.
+-- Renumber existing lanes in their current order to close gaps and break ties.
+
+UPDATE tasks t
+SET position = r.n - 1
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY board_id, status ORDER BY position, created_at, id) AS n
+    FROM tasks
+    WHERE archived_at IS NULL
+) r
+WHERE t.id = r.id AND t.position <> r.n - 1;
+
+CREATE INDEX idx_tasks_lane_position ON tasks (board_id, status, position) WHERE archived_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX idx_tasks_lane_position;
@@ -0,0 +1,37 @@
+-- +goose Up
+-- Tasks are ordered within their lane by a lexicographic rank instead of an
+-- integer position, so a move rewrites only the moved task. Ranks compare
+-- bytewise, hence the C collation. Existing lanes keep their order; each task
+-- gets a fixed-width rank that the rebalancer shortens later if needed.
+
+ALTER TABLE tasks ADD COLUMN rank TEXT COLLATE "C";
+
+UPDATE tasks t
+SET rank = lpad(r.n::text, 6, '0') || 'i'
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY board_id, status ORDER BY archived_at IS NOT NULL, position, created_at, id) AS n
+    FROM tasks
+) r
+WHERE t.id = r.id;
+
+ALTER TABLE tasks ALTER COLUMN rank SET NOT NULL;
+DROP INDEX idx_tasks_lane_position;
+ALTER TABLE tasks DROP COLUMN position;
+
+CREATE INDEX idx_tasks_lane_rank ON tasks (board_id, status, rank) WHERE archived_at IS NULL;
+
+-- +goose Down
+
+DROP INDEX idx_tasks_lane_rank;
+ALTER TABLE tasks ADD COLUMN position INTEGER NOT NULL DEFAULT 0;
+
+UPDATE tasks t
+SET position = r.n - 1
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY board_id, status ORDER BY archived_at IS NOT NULL, rank, id) AS n
+    FROM tasks
+) r
+WHERE t.id = r.id;
+
+CREATE INDEX idx_tasks_lane_position ON tasks (board_id, status, position) WHERE archived_at IS NULL;
+ALTER TABLE tasks DROP COLUMN rank;
@@ -0,0 +1,19 @@
+-- +goose Up
+-- Responses stored for Idempotency-Key headers. key_hash is a SHA-256 of the
+-- key and the request it was sent with; status is 0 while the first request
+-- is still running.
+
+CREATE TABLE idempotency_keys (
+    key_hash TEXT PRIMARY KEY,
+    fingerprint TEXT NOT NULL,
+    status INTEGER NOT NULL DEFAULT 0,
+    headers JSONB NOT NULL DEFAULT '{}',
+    body BYTEA,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_idempotency_keys_created_at ON idempotency_keys (created_at);
+
+-- +goose Down
+
+DROP TABLE idempotency_keys;
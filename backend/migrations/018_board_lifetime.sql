@@ -0,0 +1,11 @@
+-- +goose Up
+-- Boards carry their own lifetime, used when they are extended and, with
+-- sliding expiry, on every change. Existing boards keep the old 7 days.
+
+ALTER TABLE boards ADD COLUMN ttl_seconds INTEGER NOT NULL DEFAULT 604800;
+ALTER TABLE boards ADD COLUMN sliding_expiry BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+
+ALTER TABLE boards DROP COLUMN sliding_expiry;
+ALTER TABLE boards DROP COLUMN ttl_seconds;
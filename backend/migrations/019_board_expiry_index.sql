@@ -0,0 +1,8 @@
+-- +goose Up
+-- Lets the reaper find boards past expires_at without scanning every board.
+
+CREATE INDEX idx_boards_expires_at ON boards (expires_at);
+
+-- +goose Down
+
+DROP INDEX idx_boards_expires_at;
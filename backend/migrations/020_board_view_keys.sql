@@ -0,0 +1,16 @@
+-- +goose Up
+-- Read-only keys for sharing a board. Each grants GET access to one board;
+-- deleting the row revokes it.
+
+CREATE TABLE board_view_keys (
+    id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    board_id UUID NOT NULL REFERENCES boards(id) ON DELETE CASCADE,
+    key VARCHAR(255) UNIQUE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP NOT NULL
+);
+
+CREATE INDEX idx_board_view_keys_board_id ON board_view_keys (board_id, created_at);
+
+-- +goose Down
+
+DROP TABLE board_view_keys;
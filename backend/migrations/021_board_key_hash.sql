@@ -0,0 +1,24 @@
+-- +goose Up
+-- Boards are looked up by the hex SHA-256 of their key; the key itself is
+-- only ever shown in the response that creates the board. Stored idempotent
+-- responses may hold plaintext keys from before their bodies were encrypted,
+-- so they are dropped; a retry of one of those requests creates a new board.
+
+ALTER TABLE boards ADD COLUMN key_hash CHAR(64);
+UPDATE boards SET key_hash = encode(sha256(convert_to(key, 'UTF8')), 'hex');
+ALTER TABLE boards ALTER COLUMN key_hash SET NOT NULL;
+ALTER TABLE boards ADD CONSTRAINT boards_key_hash_key UNIQUE (key_hash);
+ALTER TABLE boards DROP COLUMN key;
+
+DELETE FROM idempotency_keys;
+
+-- +goose Down
+-- The plaintext keys cannot be recovered. Rolling back leaves every board
+-- unreachable by its old key, so boards are kept under their hash instead.
+
+ALTER TABLE boards ADD COLUMN key VARCHAR(255);
+UPDATE boards SET key = key_hash;
+ALTER TABLE boards ALTER COLUMN key SET NOT NULL;
+ALTER TABLE boards ADD CONSTRAINT boards_key_key UNIQUE (key);
+CREATE INDEX idx_boards_key ON boards (key);
+ALTER TABLE boards DROP COLUMN key_hash;
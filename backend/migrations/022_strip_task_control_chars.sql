@@ -0,0 +1,14 @@
+-- +goose Up
+-- Task text is now stored without C0 control characters other than tab,
+-- newline and carriage return; search highlighting uses \x02 and \x03 as
+-- markers. Clean up tasks written before that.
+
+UPDATE tasks
+SET title = regexp_replace(title, '[\x01-\x08\x0b\x0c\x0e-\x1f]', '', 'g'),
+    description = regexp_replace(description, '[\x01-\x08\x0b\x0c\x0e-\x1f]', '', 'g')
+WHERE title ~ '[\x01-\x08\x0b\x0c\x0e-\x1f]'
+   OR description ~ '[\x01-\x08\x0b\x0c\x0e-\x1f]';
+
+-- +goose Down
+
+-- The removed characters cannot be restored.
@@ -0,0 +1,22 @@
+-- +goose Up
+-- View keys are looked up by the hex SHA-256 of the key, like board keys.
+-- Only the first characters are kept in plaintext, so that a board's owner
+-- can tell its view keys apart when listing them.
+
+ALTER TABLE board_view_keys ADD COLUMN key_hash CHAR(64);
+ALTER TABLE board_view_keys ADD COLUMN key_prefix VARCHAR(16);
+UPDATE board_view_keys
+SET key_hash = encode(sha256(convert_to(key, 'UTF8')), 'hex'),
+    key_prefix = left(key, 6);
+ALTER TABLE board_view_keys ALTER COLUMN key_hash SET NOT NULL;
+ALTER TABLE board_view_keys ALTER COLUMN key_prefix SET NOT NULL;
+ALTER TABLE board_view_keys ADD CONSTRAINT board_view_keys_key_hash_key UNIQUE (key_hash);
+ALTER TABLE board_view_keys DROP COLUMN key;
+
+-- +goose Down
+-- The plaintext keys cannot be recovered, so existing view keys are revoked.
+
+DELETE FROM board_view_keys;
+ALTER TABLE board_view_keys ADD COLUMN key VARCHAR(255) UNIQUE NOT NULL;
+ALTER TABLE board_view_keys DROP COLUMN key_prefix;
+ALTER TABLE board_view_keys DROP COLUMN key_hash;
@@ -0,0 +1,9 @@
+-- +goose Up
+-- Each claim on an idempotency key carries a random token, so that a request
+-- whose lease ran out cannot store over or release the claim that replaced it.
+
+ALTER TABLE idempotency_keys ADD COLUMN claim_token UUID NOT NULL DEFAULT gen_random_uuid();
+
+-- +goose Down
+
+ALTER TABLE idempotency_keys DROP COLUMN claim_token;
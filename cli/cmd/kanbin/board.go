@@ -2,7 +2,12 @@ package main
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/zeeshanejaz/kanbin/cli/internal/client"
@@ -17,31 +22,102 @@ func newBoardCmd() *cobra.Command {
 	cmd.AddCommand(newBoardCreateCmd())
 	cmd.AddCommand(newBoardViewCmd())
 	cmd.AddCommand(newBoardDeleteCmd())
+	cmd.AddCommand(newBoardExtendCmd())
+	cmd.AddCommand(newBoardViewKeyCmd())
+	cmd.AddCommand(newBoardLogCmd())
 
 	return cmd
 }
 
+// parseTTL parses a board lifetime such as 36h or 14d into whole seconds.
+// Go durations have no day unit, so a trailing "d" is handled here.
+func parseTTL(s string) (int, error) {
+	var d time.Duration
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+		d = time.Duration(n) * 24 * time.Hour
+	} else {
+		var err error
+		if d, err = time.ParseDuration(s); err != nil {
+			return 0, fmt.Errorf("invalid TTL %q", s)
+		}
+	}
+	return int(d.Seconds()), nil
+}
+
 func newBoardCreateCmd() *cobra.Command {
-	return &cobra.Command{
+	var ttl string
+	var sliding bool
+	cmd := &cobra.Command{
 		Use:   "create [title]",
 		Short: "Create a new board",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
-			title := args[0]
+			body := map[string]interface{}{"title": args[0], "sliding_expiry": sliding}
+			if ttl != "" {
+				seconds, err := parseTTL(ttl)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				body["ttl_seconds"] = seconds
+			}
+
 			var result struct {
-				Key   string `json:"key"`
-				Title string `json:"title"`
+				Key       string `json:"key"`
+				Title     string `json:"title"`
+				ExpiresAt string `json:"expires_at"`
 			}
-			err := client.Post("/boards", map[string]string{"title": title}, &result)
+			err := client.Create("/boards", body, &result)
 			if err != nil {
 				fmt.Printf("Error creating board: %v\n", err)
 				os.Exit(1)
 			}
 			fmt.Printf("Board created successfully!\n")
-			fmt.Printf("Title: %s\n", result.Title)
-			fmt.Printf("Key:   %s\n", result.Key)
+			fmt.Printf("Title:   %s\n", result.Title)
+			fmt.Printf("Key:     %s\n", result.Key)
+			fmt.Printf("Expires: %s\n", result.ExpiresAt)
+		},
+	}
+	cmd.Flags().StringVar(&ttl, "ttl", "", "Board lifetime, e.g. 36h or 14d (default 7d)")
+	cmd.Flags().BoolVar(&sliding, "sliding", false, "Push the expiry forward whenever the board changes")
+	return cmd
+}
+
+func newBoardExtendCmd() *cobra.Command {
+	var ttl string
+	cmd := &cobra.Command{
+		Use:   "extend [key]",
+		Short: "Renew a board's lifetime from now",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			key := args[0]
+			body := map[string]interface{}{}
+			if ttl != "" {
+				seconds, err := parseTTL(ttl)
+				if err != nil {
+					fmt.Printf("Error: %v\n", err)
+					os.Exit(1)
+				}
+				body["ttl_seconds"] = seconds
+			}
+
+			var result struct {
+				ExpiresAt string `json:"expires_at"`
+			}
+			err := client.Post(fmt.Sprintf("/boards/%s/extend", key), body, &result)
+			if err != nil {
+				fmt.Printf("Error extending board: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Board %s now expires at %s.\n", key, result.ExpiresAt)
 		},
 	}
+	cmd.Flags().StringVar(&ttl, "ttl", "", "New lifetime, e.g. 36h or 14d (default: the board's current lifetime)")
+	return cmd
 }
 
 func newBoardViewCmd() *cobra.Command {
@@ -52,9 +128,9 @@ func newBoardViewCmd() *cobra.Command {
 		Run: func(cmd *cobra.Command, args []string) {
 			key := args[0]
 			var result struct {
-				Key   string `json:"key"`
-				Title string `json:"title"`
-				Tasks []struct {
+				Title  string `json:"title"`
+				Access string `json:"access"`
+				Tasks  []struct {
 					ID          string `json:"id"`
 					Title       string `json:"title"`
 					Status      string `json:"status"`
@@ -67,7 +143,11 @@ func newBoardViewCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
-			fmt.Printf("=== %s [%s] ===\n\n", result.Title, result.Key)
+			if result.Access == "view" {
+				fmt.Printf("=== %s [read-only] ===\n\n", result.Title)
+			} else {
+				fmt.Printf("=== %s [%s] ===\n\n", result.Title, key)
+			}
 			if len(result.Tasks) == 0 {
 				fmt.Println("No tasks on this board.")
 				return
@@ -99,3 +179,138 @@ func newBoardDeleteCmd() *cobra.Command {
 		},
 	}
 }
+
+func newBoardViewKeyCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "view-key",
+		Short: "Manage read-only keys for sharing a board",
+	}
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "create [key]",
+		Short: "Create a view key that can read the board but not change it",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var result struct {
+				ID  string `json:"id"`
+				Key string `json:"key"`
+			}
+			err := client.Post(fmt.Sprintf("/boards/%s/view-keys", args[0]), nil, &result)
+			if err != nil {
+				fmt.Printf("Error creating view key: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("View key created successfully!\n")
+			fmt.Printf("ID:  %s\n", result.ID)
+			fmt.Printf("Key: %s\n", result.Key)
+			fmt.Println("The key cannot be shown again; keep it somewhere safe.")
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "list [key]",
+		Short: "List a board's view keys",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			var result []struct {
+				ID        string `json:"id"`
+				Prefix    string `json:"prefix"`
+				CreatedAt string `json:"created_at"`
+			}
+			err := client.Get(fmt.Sprintf("/boards/%s/view-keys", args[0]), &result)
+			if err != nil {
+				fmt.Printf("Error fetching view keys: %v\n", err)
+				os.Exit(1)
+			}
+			if len(result) == 0 {
+				fmt.Println("No view keys on this board.")
+				return
+			}
+			for _, vk := range result {
+				fmt.Printf("%s  %s...  created %s\n", vk.ID, vk.Prefix, vk.CreatedAt)
+			}
+		},
+	})
+
+	cmd.AddCommand(&cobra.Command{
+		Use:   "revoke [key] [view-key-id]",
+		Short: "Revoke a view key",
+		Args:  cobra.ExactArgs(2),
+		Run: func(cmd *cobra.Command, args []string) {
+			var result map[string]string
+			err := client.Delete(fmt.Sprintf("/boards/%s/view-keys/%s", args[0], args[1]), &result)
+			if err != nil {
+				fmt.Printf("Error revoking view key: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("View key %s revoked.\n", args[1])
+		},
+	})
+
+	return cmd
+}
+
+func newBoardLogCmd() *cobra.Command {
+	var limit int
+	var cursor string
+	cmd := &cobra.Command{
+		Use:   "log [key]",
+		Short: "Show a board's activity log, newest first",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			key := args[0]
+			query := url.Values{}
+			query.Set("limit", strconv.Itoa(limit))
+			if cursor != "" {
+				query.Set("cursor", cursor)
+			}
+
+			var result struct {
+				Entries []struct {
+					TaskID  string `json:"task_id"`
+					Action  string `json:"action"`
+					Actor   string `json:"actor"`
+					Changes map[string]struct {
+						Before interface{} `json:"before"`
+						After  interface{} `json:"after"`
+					} `json:"changes"`
+					CreatedAt string `json:"created_at"`
+				} `json:"entries"`
+				NextCursor string `json:"next_cursor"`
+			}
+			err := client.Get(fmt.Sprintf("/boards/%s/activity?%s", key, query.Encode()), &result)
+			if err != nil {
+				fmt.Printf("Error fetching activity: %v\n", err)
+				os.Exit(1)
+			}
+			if len(result.Entries) == 0 {
+				fmt.Println("No activity on this board.")
+				return
+			}
+
+			for _, e := range result.Entries {
+				actor := e.Actor
+				if actor == "" {
+					actor = "anonymous"
+				}
+				fmt.Printf("%s  %-12s %s  by %s\n", e.CreatedAt, e.Action, e.TaskID, actor)
+
+				fields := make([]string, 0, len(e.Changes))
+				for field := range e.Changes {
+					fields = append(fields, field)
+				}
+				sort.Strings(fields)
+				for _, field := range fields {
+					c := e.Changes[field]
+					fmt.Printf("    %s: %v -> %v\n", field, c.Before, c.After)
+				}
+			}
+			if result.NextCursor != "" {
+				fmt.Printf("\nMore entries: kanbin board log %s --cursor %s\n", key, result.NextCursor)
+			}
+		},
+	}
+	cmd.Flags().IntVar(&limit, "limit", 20, "Number of entries to show (max 200)")
+	cmd.Flags().StringVar(&cursor, "cursor", "", "Continue from a previous page")
+	return cmd
+}
@@ -10,6 +10,7 @@ import (
 
 func main() {
 	var serverAddr string
+	var actor string
 
 	var rootCmd = &cobra.Command{
 		Use:   "kanbin",
@@ -18,6 +19,10 @@ func main() {
 			if serverAddr != "" {
 				client.SetBaseURL(serverAddr)
 			}
+			if actor == "" {
+				actor = os.Getenv("KANBIN_ACTOR")
+			}
+			client.SetActor(actor)
 		},
 		Run: func(cmd *cobra.Command, args []string) {
 			cmd.Help()
@@ -25,6 +30,7 @@ func main() {
 	}
 
 	rootCmd.PersistentFlags().StringVarP(&serverAddr, "server", "s", "", "Backend server URL (overrides KANBIN_URL and default)")
+	rootCmd.PersistentFlags().StringVar(&actor, "actor", "", "Name recorded in the board activity log (overrides KANBIN_ACTOR)")
 
 	// Will attach subcommands here
 	rootCmd.AddCommand(newBoardCmd())
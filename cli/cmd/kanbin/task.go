@@ -2,7 +2,10 @@ package main
 
 import (
 	"fmt"
+	"html"
+	"net/url"
 	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/zeeshanejaz/kanbin/cli/internal/client"
@@ -10,6 +13,17 @@ import (
 
 var boardKey string
 var taskStatus string
+var taskLabels []string
+var taskAssignee string
+var taskDue string
+var taskPriority string
+var taskOverdue bool
+var taskSort string
+var taskArchived bool
+var taskPurge bool
+var taskBefore string
+var taskAfter string
+var commentAuthor string
 
 func newTaskCmd() *cobra.Command {
 	var cmd = &cobra.Command{
@@ -19,8 +33,13 @@ func newTaskCmd() *cobra.Command {
 
 	cmd.AddCommand(newTaskAddCmd())
 	cmd.AddCommand(newTaskListCmd())
+	cmd.AddCommand(newTaskSearchCmd())
+	cmd.AddCommand(newTaskShowCmd())
 	cmd.AddCommand(newTaskMoveCmd())
+	cmd.AddCommand(newTaskAssignCmd())
+	cmd.AddCommand(newTaskCommentCmd())
 	cmd.AddCommand(newTaskDeleteCmd())
+	cmd.AddCommand(newTaskRestoreCmd())
 
 	return cmd
 }
@@ -37,16 +56,25 @@ func newTaskAddCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
-			payload := map[string]string{
-				"title":  title,
-				"status": "TODO",
+			// Status is omitted so the task lands in the board's first lane.
+			payload := map[string]interface{}{
+				"title": title,
+			}
+			if len(taskLabels) > 0 {
+				payload["labels"] = taskLabels
+			}
+			if taskDue != "" {
+				payload["due_at"] = taskDue
+			}
+			if taskPriority != "" {
+				payload["priority"] = strings.ToUpper(taskPriority)
 			}
 
 			var result struct {
 				ID    string `json:"id"`
 				Title string `json:"title"`
 			}
-			err := client.Post(fmt.Sprintf("/boards/%s/tasks", boardKey), payload, &result)
+			err := client.Create(fmt.Sprintf("/boards/%s/tasks", boardKey), payload, &result)
 			if err != nil {
 				fmt.Printf("Error adding task: %v\n", err)
 				os.Exit(1)
@@ -55,6 +83,9 @@ func newTaskAddCmd() *cobra.Command {
 		},
 	}
 	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	cmd.Flags().StringSliceVar(&taskLabels, "label", nil, "Label to attach (repeatable)")
+	cmd.Flags().StringVar(&taskDue, "due", "", "Due date (YYYY-MM-DD or RFC3339)")
+	cmd.Flags().StringVar(&taskPriority, "priority", "", "Priority (LOW, MEDIUM, HIGH, URGENT)")
 	return cmd
 }
 
@@ -68,24 +99,128 @@ func newTaskListCmd() *cobra.Command {
 				os.Exit(1)
 			}
 
+			query := url.Values{}
+			for _, l := range taskLabels {
+				query.Add("label", l)
+			}
+			if cmd.Flags().Changed("assignee") {
+				query.Set("assignee", taskAssignee)
+			}
+			if taskOverdue {
+				query.Set("overdue", "true")
+			}
+			if taskSort != "" {
+				query.Set("sort", taskSort)
+			}
+			if taskArchived {
+				query.Set("include", "archived")
+			}
+			if taskStatus != "" {
+				query.Set("status", taskStatus)
+			}
+			// Only fetch what is printed, a page at a time.
+			query.Set("fields", "title,status,labels,assignee,due_at,priority,archived_at")
+			query.Set("limit", "100")
+
+			type listedTask struct {
+				ID       string   `json:"id"`
+				Title    string   `json:"title"`
+				Status   string   `json:"status"`
+				Labels   []string `json:"labels"`
+				Assignee string   `json:"assignee"`
+				DueAt    string   `json:"due_at"`
+				Priority string   `json:"priority"`
+				Archived *string  `json:"archived_at"`
+			}
+			var tasks []listedTask
+			for {
+				var result struct {
+					Tasks      []listedTask `json:"tasks"`
+					NextCursor string       `json:"next_cursor"`
+				}
+				err := client.Get(fmt.Sprintf("/boards/%s?%s", boardKey, query.Encode()), &result)
+				if err != nil {
+					fmt.Printf("Error fetching tasks: %v\n", err)
+					os.Exit(1)
+				}
+				tasks = append(tasks, result.Tasks...)
+				if result.NextCursor == "" {
+					break
+				}
+				query.Set("cursor", result.NextCursor)
+			}
+			if len(tasks) == 0 {
+				fmt.Println("No tasks on this board.")
+				return
+			}
+			for _, t := range tasks {
+				line := fmt.Sprintf("[%s] %s | %s", t.Status, t.ID, t.Title)
+				if t.Priority != "" {
+					line += " !" + t.Priority
+				}
+				if t.DueAt != "" {
+					line += " due " + t.DueAt
+				}
+				if t.Assignee != "" {
+					line += " @" + t.Assignee
+				}
+				if len(t.Labels) > 0 {
+					line += " {" + strings.Join(t.Labels, ", ") + "}"
+				}
+				if t.Archived != nil {
+					line += " (archived)"
+				}
+				fmt.Println(line)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	cmd.Flags().StringSliceVar(&taskLabels, "label", nil, "Only show tasks carrying this label (repeatable)")
+	cmd.Flags().StringVar(&taskAssignee, "assignee", "", "Only show tasks assigned to this name (empty for unassigned)")
+	cmd.Flags().BoolVar(&taskOverdue, "overdue", false, "Only show open tasks past their due date")
+	cmd.Flags().StringVar(&taskSort, "sort", "", "Sort order: lane (default) or priority")
+	cmd.Flags().BoolVar(&taskArchived, "archived", false, "Include archived tasks")
+	cmd.Flags().StringVar(&taskStatus, "status", "", "Only show tasks in this lane")
+	return cmd
+}
+
+func newTaskSearchCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "search [query]",
+		Short: "Search task titles and descriptions on a board",
+		Long: `Search task titles and descriptions on a board, best match first.
+
+The query supports "quoted phrases", "or" between words, and -word to
+exclude a word.`,
+		Args: cobra.MinimumNArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			if boardKey == "" {
+				fmt.Println("Error: --board key is required")
+				os.Exit(1)
+			}
+
+			query := url.Values{}
+			query.Set("q", strings.Join(args, " "))
 			var result struct {
-				Tasks []struct {
-					ID     string `json:"id"`
-					Title  string `json:"title"`
-					Status string `json:"status"`
-				} `json:"tasks"`
+				Results []struct {
+					ID      string `json:"id"`
+					Title   string `json:"title"`
+					Status  string `json:"status"`
+					Snippet string `json:"snippet"`
+				} `json:"results"`
 			}
-			err := client.Get(fmt.Sprintf("/boards/%s", boardKey), &result)
+			err := client.Get(fmt.Sprintf("/boards/%s/tasks?%s", boardKey, query.Encode()), &result)
 			if err != nil {
-				fmt.Printf("Error fetching tasks: %v\n", err)
+				fmt.Printf("Error searching tasks: %v\n", err)
 				os.Exit(1)
 			}
-			if len(result.Tasks) == 0 {
-				fmt.Println("No tasks on this board.")
+			if len(result.Results) == 0 {
+				fmt.Println("No matching tasks.")
 				return
 			}
-			for _, t := range result.Tasks {
+			for _, t := range result.Results {
 				fmt.Printf("[%s] %s | %s\n", t.Status, t.ID, t.Title)
+				fmt.Printf("    %s\n", terminalSnippet(t.Snippet))
 			}
 		},
 	}
@@ -93,28 +228,162 @@ func newTaskListCmd() *cobra.Command {
 	return cmd
 }
 
+// terminalSnippet renders a search snippet for the terminal: <mark> tags
+// become bold and HTML entities are decoded.
+func terminalSnippet(snippet string) string {
+	snippet = strings.NewReplacer("<mark>", "\033[1m", "</mark>", "\033[0m").Replace(snippet)
+	snippet = html.UnescapeString(snippet)
+	return strings.Join(strings.Fields(snippet), " ")
+}
+
+func newTaskShowCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "show [id]",
+		Short: "Show every field of a task",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			if boardKey == "" {
+				fmt.Println("Error: --board key is required")
+				os.Exit(1)
+			}
+
+			var t struct {
+				ID          string   `json:"id"`
+				Title       string   `json:"title"`
+				Description string   `json:"description"`
+				Status      string   `json:"status"`
+				Position    int      `json:"position"`
+				Labels      []string `json:"labels"`
+				Assignee    string   `json:"assignee"`
+				DueAt       string   `json:"due_at"`
+				Priority    string   `json:"priority"`
+				ArchivedAt  string   `json:"archived_at"`
+				CreatedAt   string   `json:"created_at"`
+				UpdatedAt   string   `json:"updated_at"`
+				Version     int      `json:"version"`
+				Checklist   *struct {
+					Done  int `json:"done"`
+					Total int `json:"total"`
+				} `json:"checklist"`
+				BlockedBy []string `json:"blocked_by"`
+				Blocks    []string `json:"blocks"`
+			}
+			err := client.Get(fmt.Sprintf("/boards/%s/tasks/%s", boardKey, id), &t)
+			if err != nil {
+				fmt.Printf("Error fetching task: %v\n", err)
+				os.Exit(1)
+			}
+
+			orNone := func(s string) string {
+				if s == "" {
+					return "-"
+				}
+				return s
+			}
+			fmt.Printf("ID:          %s\n", t.ID)
+			fmt.Printf("Title:       %s\n", t.Title)
+			fmt.Printf("Status:      %s\n", t.Status)
+			fmt.Printf("Position:    %d\n", t.Position)
+			fmt.Printf("Priority:    %s\n", orNone(t.Priority))
+			fmt.Printf("Due:         %s\n", orNone(t.DueAt))
+			fmt.Printf("Assignee:    %s\n", orNone(t.Assignee))
+			fmt.Printf("Labels:      %s\n", orNone(strings.Join(t.Labels, ", ")))
+			if t.Checklist != nil {
+				fmt.Printf("Checklist:   %d/%d done\n", t.Checklist.Done, t.Checklist.Total)
+			} else {
+				fmt.Printf("Checklist:   -\n")
+			}
+			fmt.Printf("Blocked by:  %s\n", orNone(strings.Join(t.BlockedBy, ", ")))
+			fmt.Printf("Blocks:      %s\n", orNone(strings.Join(t.Blocks, ", ")))
+			fmt.Printf("Created:     %s\n", t.CreatedAt)
+			fmt.Printf("Updated:     %s (version %d)\n", t.UpdatedAt, t.Version)
+			if t.ArchivedAt != "" {
+				fmt.Printf("Archived:    %s\n", t.ArchivedAt)
+			}
+			fmt.Printf("\n%s\n", orNone(t.Description))
+		},
+	}
+	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	return cmd
+}
+
 func newTaskMoveCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "move [id]",
-		Short: "Move a task (change status)",
+		Short: "Move a task (change status or order)",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			id := args[0]
 			if taskStatus == "" {
-				fmt.Println("Error: --status is required (TODO, IN_PROGRESS, DONE)")
+				fmt.Println("Error: --status is required (the name of one of the board's lanes)")
 				os.Exit(1)
 			}
 			if boardKey == "" {
 				fmt.Println("Error: --board key is required")
 				os.Exit(1)
 			}
+			if taskBefore != "" && taskAfter != "" {
+				fmt.Println("Error: use only one of --before and --after")
+				os.Exit(1)
+			}
 
 			payload := map[string]interface{}{
 				"status": taskStatus,
 			}
+			if taskBefore != "" {
+				payload["before"] = taskBefore
+			}
+			if taskAfter != "" {
+				payload["after"] = taskAfter
+			}
 			var task struct {
-				ID     string `json:"id"`
-				Status string `json:"status"`
+				ID       string `json:"id"`
+				Status   string `json:"status"`
+				Position int    `json:"position"`
+			}
+			var err error
+			if taskBefore != "" || taskAfter != "" {
+				err = client.Post(fmt.Sprintf("/boards/%s/tasks/%s/move", boardKey, id), payload, &task)
+			} else {
+				err = client.Put(fmt.Sprintf("/boards/%s/tasks/%s", boardKey, id), payload, &task)
+			}
+			if err != nil {
+				fmt.Printf("Error moving task %s: %v\n", id, err)
+				os.Exit(1)
+			}
+			fmt.Printf("Task %s moved to %s (position %d)\n", id, task.Status, task.Position)
+		},
+	}
+	cmd.Flags().StringVar(&taskStatus, "status", "", "New status: a lane name on the board (e.g. TODO, IN_PROGRESS, DONE)")
+	cmd.Flags().StringVar(&taskBefore, "before", "", "Place the task directly before this task ID in the lane")
+	cmd.Flags().StringVar(&taskAfter, "after", "", "Place the task directly after this task ID in the lane")
+	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	return cmd
+}
+
+func newTaskAssignCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "assign [id] [assignee]",
+		Short: "Assign a task (omit assignee to unassign)",
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			assignee := ""
+			if len(args) == 2 {
+				assignee = args[1]
+			}
+			if boardKey == "" {
+				fmt.Println("Error: --board key is required")
+				os.Exit(1)
+			}
+
+			payload := map[string]interface{}{
+				"assignee": assignee,
+			}
+			var task struct {
+				ID       string `json:"id"`
+				Assignee string `json:"assignee"`
 			}
 			err := client.Put(
 				fmt.Sprintf("/boards/%s/tasks/%s", boardKey, id),
@@ -122,21 +391,89 @@ func newTaskMoveCmd() *cobra.Command {
 				&task,
 			)
 			if err != nil {
-				fmt.Printf("Error updating task %s: %v\n", id, err)
+				fmt.Printf("Error assigning task %s: %v\n", id, err)
+				os.Exit(1)
+			}
+			if task.Assignee == "" {
+				fmt.Printf("Task %s unassigned\n", id)
+			} else {
+				fmt.Printf("Task %s assigned to %s\n", id, task.Assignee)
+			}
+		},
+	}
+	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	return cmd
+}
+
+func newTaskCommentCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "comment [id] [message]",
+		Short: "Comment on a task, or list its comments when no message is given",
+		Args:  cobra.RangeArgs(1, 2),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			if boardKey == "" {
+				fmt.Println("Error: --board key is required")
+				os.Exit(1)
+			}
+			path := fmt.Sprintf("/boards/%s/tasks/%s/comments", boardKey, id)
+
+			type comment struct {
+				ID        string `json:"id"`
+				Author    string `json:"author"`
+				Body      string `json:"body"`
+				CreatedAt string `json:"created_at"`
+			}
+
+			if len(args) == 1 {
+				var comments []comment
+				if err := client.Get(path, &comments); err != nil {
+					fmt.Printf("Error fetching comments: %v\n", err)
+					os.Exit(1)
+				}
+				if len(comments) == 0 {
+					fmt.Println("No comments on this task.")
+					return
+				}
+				for _, c := range comments {
+					fmt.Printf("%s  %s (%s)\n  %s\n", c.CreatedAt, c.Author, c.ID, c.Body)
+				}
+				return
+			}
+
+			author := commentAuthor
+			if author == "" {
+				author = client.Actor()
+			}
+			if author == "" {
+				author = os.Getenv("USER")
+			}
+			if author == "" {
+				fmt.Println("Error: --author is required (or set --actor / KANBIN_ACTOR)")
+				os.Exit(1)
+			}
+
+			payload := map[string]string{
+				"author": author,
+				"body":   args[1],
+			}
+			var result comment
+			if err := client.Post(path, payload, &result); err != nil {
+				fmt.Printf("Error adding comment: %v\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("Task %s moved to %s\n", id, taskStatus)
+			fmt.Printf("Comment [%s] added to task %s\n", result.ID, id)
 		},
 	}
-	cmd.Flags().StringVar(&taskStatus, "status", "", "New status (TODO, IN_PROGRESS, DONE)")
 	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	cmd.Flags().StringVar(&commentAuthor, "author", "", "Comment author (defaults to --actor, then USER)")
 	return cmd
 }
 
 func newTaskDeleteCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "delete [id]",
-		Short: "Delete a task",
+		Short: "Archive a task, or permanently delete an archived one with --purge",
 		Args:  cobra.ExactArgs(1),
 		Run: func(cmd *cobra.Command, args []string) {
 			id := args[0]
@@ -144,16 +481,47 @@ func newTaskDeleteCmd() *cobra.Command {
 				fmt.Println("Error: --board key is required")
 				os.Exit(1)
 			}
+			path := fmt.Sprintf("/boards/%s/tasks/%s", boardKey, id)
+			if taskPurge {
+				path += "/purge"
+			}
 			var result map[string]string
-			err := client.Delete(
-				fmt.Sprintf("/boards/%s/tasks/%s", boardKey, id),
-				&result,
-			)
-			if err != nil {
+			if err := client.Delete(path, &result); err != nil {
 				fmt.Printf("Error deleting task: %v\n", err)
 				os.Exit(1)
 			}
-			fmt.Printf("Task %s deleted.\n", id)
+			if taskPurge {
+				fmt.Printf("Task %s permanently deleted.\n", id)
+				return
+			}
+			fmt.Printf("Task %s archived. Undo with: kanbin task restore %s --board %s\n", id, id, boardKey)
+		},
+	}
+	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
+	cmd.Flags().BoolVar(&taskPurge, "purge", false, "Permanently delete an already archived task")
+	return cmd
+}
+
+func newTaskRestoreCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "restore [id]",
+		Short: "Restore an archived task",
+		Args:  cobra.ExactArgs(1),
+		Run: func(cmd *cobra.Command, args []string) {
+			id := args[0]
+			if boardKey == "" {
+				fmt.Println("Error: --board key is required")
+				os.Exit(1)
+			}
+			var result struct {
+				Status string `json:"status"`
+			}
+			err := client.Post(fmt.Sprintf("/boards/%s/tasks/%s/restore", boardKey, id), nil, &result)
+			if err != nil {
+				fmt.Printf("Error restoring task: %v\n", err)
+				os.Exit(1)
+			}
+			fmt.Printf("Task %s restored to %s.\n", id, result.Status)
 		},
 	}
 	cmd.Flags().StringVar(&boardKey, "board", "", "Board key (required)")
@@ -2,19 +2,36 @@ package client
 
 import (
 	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"os"
+	"time"
 )
 
 var baseURLOverride string
+var actor string
 
 func SetBaseURL(url string) {
 	baseURLOverride = url
 }
 
+// SetActor sets the name sent with every request so the server can attribute
+// changes in the board activity log.
+func SetActor(name string) {
+	actor = name
+}
+
+// Actor returns the name set by SetActor.
+func Actor() string {
+	return actor
+}
+
 func getBaseURL() string {
 	if baseURLOverride != "" {
 		return baseURLOverride
@@ -51,6 +68,10 @@ func parseResponse(resp *http.Response, target interface{}) error {
 }
 
 func doRequest(method, path string, payload interface{}, target interface{}) error {
+	return send(method, path, payload, target, nil)
+}
+
+func send(method, path string, payload interface{}, target interface{}, header http.Header) error {
 	url := getBaseURL() + path
 	var bodyReader io.Reader
 
@@ -68,6 +89,12 @@ func doRequest(method, path string, payload interface{}, target interface{}) err
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if actor != "" {
+		req.Header.Set("X-Kanbin-Actor", actor)
+	}
 
 	client := &http.Client{}
 	resp, err := client.Do(req)
@@ -86,6 +113,31 @@ func Post(path string, payload interface{}, target interface{}) error {
 	return doRequest(http.MethodPost, path, payload, target)
 }
 
+// createAttempts is how many times Create sends a request that fails to
+// reach the server.
+const createAttempts = 3
+
+// Create sends a POST that creates something, with an Idempotency-Key so
+// that it can be retried after a network error without creating a duplicate.
+func Create(path string, payload interface{}, target interface{}) error {
+	key := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return err
+	}
+	header := http.Header{"Idempotency-Key": {hex.EncodeToString(key)}}
+
+	var err error
+	for attempt := 1; attempt <= createAttempts; attempt++ {
+		err = send(http.MethodPost, path, payload, target, header)
+		var netErr net.Error
+		if !errors.As(err, &netErr) || attempt == createAttempts {
+			return err
+		}
+		time.Sleep(time.Duration(attempt) * 500 * time.Millisecond)
+	}
+	return err
+}
+
 // Put sends a PUT request.
 func Put(path string, payload interface{}, target interface{}) error {
 	return doRequest(http.MethodPut, path, payload, target)
@@ -24,7 +24,8 @@ export interface Task {
 
 export interface Board {
     id: string;
-    key: string;
+    // Only returned when the board is created; the server keeps just a hash.
+    key?: string;
     title: string;
     created_at: string;
     expires_at: string;
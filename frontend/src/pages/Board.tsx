@@ -244,7 +244,7 @@ export default function BoardView() {
                     <div>
                         <h1 className="board-title">{boardData.title}</h1>
                         <div className="board-meta">
-                            <span>Key: <strong>{boardData.key}</strong></span>
+                            <span>Key: <strong>{key}</strong></span>
                             <button 
                                 className="copy-link-btn" 
                                 onClick={handleCopyLink}